@@ -0,0 +1,80 @@
+package epd
+
+import (
+	"image"
+	"time"
+)
+
+// slowTransfer is the average duration of a single row transfer above which the transport is considered to have
+// a high per-transfer overhead (such as USB-SPI bridges), and the rows are transmitted in a few large transfers instead
+const slowTransfer = time.Millisecond
+
+// chunks is the number of transfers the rows are split into once transmitting in large transfers
+const chunks = 8
+
+// Throughput returns the throughput (in bytes per second) of writing into the device's RAM, as measured
+// over all the frames and regions drawn so far. It returns zero if nothing has been drawn yet.
+//
+// The throughput of the first full frame is also used to adapt to the transport: if the overhead of each
+// transfer turns out to be high, the following frames are sent in a few large transfers rather than one per row.
+func (epd *EPD) Throughput() float64 {
+	if epd.spent <= 0 {
+		return 0
+	}
+	return float64(epd.sent) / epd.spent.Seconds()
+}
+
+// measure records a transfer of n bytes that started at the given time
+func (epd *EPD) measure(n int, start time.Time) {
+	epd.sent, epd.spent, epd.transfers = epd.sent+n, epd.spent+time.Since(start), epd.transfers+1
+}
+
+// calibrate switches to transmitting in large transfers (see writeChunk) if the transfers of the first full frame were slow
+// spent and transfers are the statistics from before the frame was written into the region r.
+func (epd *EPD) calibrate(r image.Rectangle, spent time.Duration, transfers int) {
+	if epd.measured || r != epd.bounds() || epd.transfers == transfers {
+		return
+	}
+	epd.measured = true
+	epd.chunked = (epd.spent-spent)/time.Duration(epd.transfers-transfers) > slowTransfer
+}
+
+// writeChunk transmits the rows of the packed buffer covered by r (which must be aligned to byte boundaries)
+// in a few large transfers of several rows each, relying on the device to wrap around the window set up by writeRect
+// Progress is reported (and cancellation checked for) between the transfers.
+func (epd *EPD) writeChunk(ram byte, buf []byte, r image.Rectangle) error {
+	var rows = (r.Dy() + chunks - 1) / chunks
+	for y := r.Min.Y; y < r.Max.Y; y += rows {
+		if err := epd.cancelled(); err != nil {
+			return err
+		}
+
+		var end = y + rows
+		if end > r.Max.Y {
+			end = r.Max.Y
+		}
+		if err := epd.cursor(byte(r.Min.X), uint16(y)); err != nil {
+			return err
+		}
+		epd.writeRAM(ram, epd.chunk(buf, image.Rect(r.Min.X, y, r.Max.X, end)))
+
+		if epd.progress != nil {
+			epd.progress(end-r.Min.Y, r.Dy())
+		}
+	}
+	return nil
+}
+
+// chunk returns the rows of the packed buffer covered by r (which must be aligned to byte boundaries) back to back
+func (epd *EPD) chunk(buf []byte, r image.Rectangle) []byte {
+	var stride, x0, x1 = epd.stride(), r.Min.X / 8, r.Max.X / 8
+	if x1-x0 == stride { // the rows are contiguous in the buffer
+		return buf[r.Min.Y*stride : r.Max.Y*stride]
+	}
+
+	var chunk = make([]byte, 0, (x1-x0)*r.Dy())
+	for i := r.Min.Y; i < r.Max.Y; i++ {
+		chunk = append(chunk, buf[i*stride+x0:i*stride+x1]...)
+	}
+	return chunk
+}
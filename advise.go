@@ -0,0 +1,36 @@
+package epd
+
+import (
+	"image"
+	"math/bits"
+)
+
+// thresholds used by ShouldFullUpdate
+const (
+	fullUpdateChanged  = 0.5 // fraction of the display's pixels that can change before a full update is advised
+	fullUpdatePartials = 10  // number of consecutive partial updates after which a full update is advised
+)
+
+// ShouldFullUpdate reports whether a full update is advisable for going from the prev frame to the next one
+//
+// A full update is advised if more than half of the display's pixels would change, or if the display has been
+// refreshed using 10 or more partial updates since the last full update, as ghosting builds up by then.
+// Both the frames are run through the configured Pipeline and packed exactly like Draw would. If either of them
+// can't be drawn onto the display, a full update is advised.
+func (epd *EPD) ShouldFullUpdate(prev, next image.Image) bool {
+	if epd.partials >= fullUpdatePartials {
+		return true
+	}
+
+	prev, next = epd.preprocess(prev), epd.preprocess(next)
+	if !epd.Fits(prev) || !epd.Fits(next) {
+		return true
+	}
+
+	var a, b = epd.pack(prev), epd.pack(next)
+	var changed int
+	for i := range a {
+		changed += bits.OnesCount8(a[i] ^ b[i])
+	}
+	return float64(changed) > fullUpdateChanged*float64(epd.Width*epd.Height)
+}
@@ -0,0 +1,48 @@
+package epd
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"unicode"
+)
+
+// DrawASCII renders ASCII art onto the display, mapping every non-space character to a dark pixel
+//
+// Each character is drawn as an NxN block, with N being the largest scale at which the art still fits
+// the display, starting at its top-left corner. Lines shorter than the longest one (and the rest of the display)
+// are padded with the Background. It's meant for bring-up and smoke tests, where it helps verify wiring and orientation
+// without building images. The Pipeline is not applied.
+func (epd *EPD) DrawASCII(lines []string) error {
+	var w, h = 0, len(lines)
+	var art = make([][]rune, h)
+	for i, line := range lines {
+		if art[i] = []rune(line); len(art[i]) > w {
+			w = len(art[i])
+		}
+	}
+	if w == 0 || w > epd.Width || h > epd.Height {
+		return ErrInvalidImageSize
+	}
+
+	var n = epd.Width / w
+	if epd.Height/h < n {
+		n = epd.Height / h
+	}
+
+	var img = image.NewGray(epd.bounds())
+	draw.Draw(img, img.Bounds(), image.NewUniform(epd.background()), image.Point{}, draw.Src)
+	for y, line := range art {
+		for x, c := range line {
+			if !unicode.IsSpace(c) {
+				var block = image.Rect(x*n, y*n, (x+1)*n, (y+1)*n)
+				for py := block.Min.Y; py < block.Max.Y; py++ {
+					for px := block.Min.X; px < block.Max.X; px++ {
+						img.SetGray(px, py, color.Gray{})
+					}
+				}
+			}
+		}
+	}
+	return epd.render(img)
+}
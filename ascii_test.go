@@ -0,0 +1,28 @@
+package epd
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+)
+
+func TestDrawASCII(t *testing.T) {
+	var tests = []struct {
+		background color.Color
+		want       []byte
+	}{
+		{color.White, []byte{0x3F, 0xFF, 0x3F, 0xFF}},
+		{color.Black, []byte{0x00, 0x00, 0x00, 0x00}}, // an inverted display is padded with black
+	}
+
+	for _, test := range tests {
+		var epd, rec = newTestEPD(16, 2)
+		epd.Background = test.background
+		if err := epd.DrawASCII([]string{"#"}); err != nil {
+			t.Fatalf("DrawASCII() failed: %v", err)
+		}
+		if got := written(rec, ramBlack); !bytes.Equal(got, test.want) {
+			t.Errorf("DrawASCII() over %v wrote % X, want % X", test.background, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package epd
+
+import (
+	"context"
+	"image"
+)
+
+// Pending is a draw queued using DrawAsync
+type Pending struct {
+	epd    *EPD
+	img    image.Image
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// DrawAsync queues the given image to be rendered onto the display (as Draw would) in the background
+// Queued draws are rendered one after the other in the order they were queued.
+//
+// The returned handle can be used to wait for the draw to complete or to cancel it, such as when newer data
+// arrives before a slow refresh has begun. No other methods of the driver should be called while draws are queued.
+func (epd *EPD) DrawAsync(img image.Image) *Pending {
+	var ctx, cancel = context.WithCancel(context.Background())
+	var p = &Pending{epd: epd, img: img, ctx: ctx, cancel: cancel, done: make(chan struct{})}
+
+	epd.mu.Lock()
+	defer epd.mu.Unlock()
+	epd.queue = append(epd.queue, p)
+	if !epd.draining {
+		epd.draining = true
+		go epd.drain()
+	}
+	return p
+}
+
+// Wait blocks until the draw completes (or is cancelled) and returns its error
+// A cancelled draw returns context.Canceled.
+func (p *Pending) Wait() error {
+	<-p.done
+	return p.err
+}
+
+// Cancel cancels the draw. A draw that hasn't started yet is removed from the queue; a draw in progress stops
+// before transmitting the next row of the image, leaving the device ready for the next command, and skips the refresh.
+// Cancelling a completed draw has no effect.
+func (p *Pending) Cancel() {
+	p.cancel()
+
+	p.epd.mu.Lock()
+	defer p.epd.mu.Unlock()
+	for i, q := range p.epd.queue {
+		if q == p {
+			p.epd.queue = append(p.epd.queue[:i], p.epd.queue[i+1:]...)
+			p.finish(p.ctx.Err())
+			return
+		}
+	}
+}
+
+// finish records the result of the draw and releases anyone waiting on it
+func (p *Pending) finish(err error) {
+	p.err = err
+	close(p.done)
+}
+
+// cancelled returns the error of the context of the draw in progress (see DrawAsync) once it's cancelled
+func (epd *EPD) cancelled() error {
+	if epd.ctx == nil {
+		return nil
+	}
+	return epd.ctx.Err()
+}
+
+// drain renders the queued draws one after the other until the queue is empty
+func (epd *EPD) drain() {
+	for {
+		epd.mu.Lock()
+		if len(epd.queue) == 0 {
+			epd.draining = false
+			epd.mu.Unlock()
+			return
+		}
+		var p = epd.queue[0]
+		epd.queue = epd.queue[1:]
+		epd.mu.Unlock()
+
+		epd.ctx = p.ctx
+		var err = epd.Draw(p.img)
+		epd.ctx = nil
+
+		if err == context.Canceled {
+			epd.dirty = true // the device holds part of the new frame; make the next Commit rewrite the frame buffer
+		}
+		p.finish(err)
+	}
+}
@@ -0,0 +1,56 @@
+package epd
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDrawCancelled(t *testing.T) {
+	for _, chunked := range []bool{false, true} {
+		var epd, rec = newTestEPD(16, 2)
+		if err := epd.Mode(FullUpdate); err != nil {
+			t.Fatalf("Mode() failed: %v", err)
+		}
+		epd.chunked = chunked
+
+		var ctx, cancel = context.WithCancel(context.Background())
+		cancel()
+		rec.Reset()
+		epd.ctx = ctx
+		var err = epd.Draw(black(16, 2))
+		epd.ctx = nil
+
+		if err != context.Canceled {
+			t.Errorf("chunked=%t: cancelled Draw() returned %v, want context.Canceled", chunked, err)
+		}
+		for _, step := range rec.Steps() {
+			if step.Command == 0x24 || step.Command == 0x20 {
+				t.Errorf("chunked=%t: cancelled Draw() sent command %#02x", chunked, step.Command)
+			}
+		}
+	}
+}
+
+func TestDrawWithProgress(t *testing.T) {
+	for _, chunked := range []bool{false, true} {
+		var epd, _ = newTestEPD(16, 32)
+		if err := epd.Mode(FullUpdate); err != nil {
+			t.Fatalf("Mode() failed: %v", err)
+		}
+		epd.chunked = chunked
+
+		var calls, last int
+		var err = epd.DrawWithProgress(black(16, 32), func(done, total int) {
+			if total != 32 || done <= last || done > total {
+				t.Errorf("chunked=%t: progress(%d, %d) after %d rows", chunked, done, total, last)
+			}
+			calls, last = calls+1, done
+		})
+		if err != nil {
+			t.Fatalf("chunked=%t: DrawWithProgress() failed: %v", chunked, err)
+		}
+		if last != 32 || calls < chunks {
+			t.Errorf("chunked=%t: progress called %d times ending at %d rows, want at least %d calls ending at 32", chunked, calls, last, chunks)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package epd
+
+// Bank identifies one of the two RAM banks of the device
+//
+// On tri-color panels the banks hold the black and the red planes of the image. On mono panels the same banks
+// hold the new image and the old (reference) image used for partial updates, hence the aliases.
+type Bank uint8
+
+const (
+	None  Bank = iota // no bank targeted; drawing renders onto the display immediately
+	Black             // bank written by command 0x24
+	Red               // bank written by command 0x26; holds the old image on mono panels (see DrawBase)
+)
+
+const (
+	NewImage = Black // new image bank on mono panels
+	OldImage = Red   // old (reference) image bank on mono panels
+)
+
+// ram returns the command used to write into the bank
+func (bank Bank) ram() byte {
+	if bank == Red {
+		return ramRed
+	}
+	return ramBlack
+}
+
+// Target selects the RAM bank that subsequent calls to Draw (and DrawBuffer) write into
+//
+// While a bank is targeted, drawing only writes into the device's RAM and doesn't refresh the display,
+// allowing both banks to be filled before calling Commit. Images are packed the same way for either bank.
+// Call Target(None) to go back to rendering onto the display immediately.
+func (epd *EPD) Target(bank Bank) {
+	epd.target = bank
+}
@@ -0,0 +1,76 @@
+package epd
+
+import "image"
+
+// DrawBase renders the given image onto the display like Draw, additionally writing it into the device's
+// OldImage bank as the base (reference) image for the following partial updates. This is how Waveshare's
+// reference driver starts a series of partial updates on V2 panels.
+//
+// On mono V2 panels the RAM bank written by 0x26 doesn't hold a red plane but the old image, which the controller
+// compares the new image against to drive only the changed pixels. V1 panels have no such bank.
+func (epd *EPD) DrawBase(img image.Image) error {
+	img = epd.preprocess(img)
+	if !epd.Fits(img) {
+		return ErrInvalidImageSize
+	}
+
+	epd.buffer = epd.pack(img)
+	epd.stamp(epd.buffer)
+	if err := epd.rebase(); err != nil {
+		return err
+	}
+	return epd.turnOnDisplay()
+}
+
+// LoadBaseImage establishes the given image as the base image for the following partial updates, like the reference
+// driver's displayPartBaseImage: the image is written into both the RAM banks (see DrawBase) and rendered using
+// a full update, after which the device is re-initialised into the mode it was in with the base image in place.
+//
+// Unlike DrawBase, which refreshes in the current mode, this guarantees a clean starting point for partial updates
+// at the cost of a flashing refresh.
+func (epd *EPD) LoadBaseImage(img image.Image) error {
+	img = epd.preprocess(img)
+	if !epd.Fits(img) {
+		return ErrInvalidImageSize
+	}
+
+	var buf = epd.pack(img)
+	epd.stamp(buf)
+	return epd.loadBase(buf)
+}
+
+// loadBase establishes the packed frame as the base image as described by LoadBaseImage
+func (epd *EPD) loadBase(buf []byte) error {
+	var mode = epd.mode
+	if err := epd.Mode(FullUpdate); err != nil {
+		return err
+	}
+	epd.buffer = buf
+	if err := epd.rebase(); err != nil {
+		return err
+	}
+	if err := epd.turnOnDisplay(); err != nil {
+		return err
+	}
+
+	if mode == FullUpdate {
+		return nil
+	}
+	if err := epd.Mode(mode); err != nil {
+		return err
+	}
+	return epd.rebase()
+}
+
+// rebase writes the frame buffer into the device's RAM as both the new and (on V2 panels) the old image
+// It's used when the display is known to show the frame buffer already, for example after waking the device up,
+// so that the following partial updates are computed against what's actually on the display.
+func (epd *EPD) rebase() error {
+	if err := epd.flush(); err != nil {
+		return err
+	}
+	if epd.Revision != V2 {
+		return nil
+	}
+	return epd.write(ramRed, epd.buffer)
+}
@@ -0,0 +1,91 @@
+package epd
+
+import "image"
+
+// Begin starts a batch of region updates
+//
+// Until End is called, DrawRegion only draws onto the driver's frame buffer and records the region,
+// without transmitting anything to the device. The device's analog circuitry is powered up (see PowerOn)
+// for the duration of the batch, unless it's already kept powered.
+func (epd *EPD) Begin() error {
+	if epd.batch != nil {
+		return nil // already in a batch
+	}
+	if !epd.powered {
+		if err := epd.PowerOn(); err != nil {
+			return err
+		}
+		epd.batchPowered = true
+	}
+	epd.batch = []image.Rectangle{}
+	return nil
+}
+
+// End ends the batch started by Begin, transmitting all the recorded regions and refreshing the display once
+//
+// Overlapping and adjacent regions are merged before being transmitted, so that the least number of
+// windows need to be set up on the device. If Begin powered the analog circuitry up, End powers it down (see PowerOff)
+// after the refresh; if it was already kept powered using PowerOn, it's left powered so that a series of batches
+// can be drawn quickly.
+func (epd *EPD) End() (err error) {
+	if epd.batchPowered {
+		defer func() {
+			epd.batchPowered = false
+			if perr := epd.PowerOff(); err == nil {
+				err = perr
+			}
+		}()
+	}
+
+	if len(epd.batch) > 0 {
+		if r := epd.stamp(epd.framebuffer()); !r.Empty() {
+			epd.batch = append(epd.batch, r) // the regions may have covered the Overlay
+		}
+	}
+	var regions = merge(epd.batch)
+	epd.batch = nil
+	if len(regions) == 0 {
+		return nil
+	}
+
+	for _, r := range regions {
+		if err := epd.writeRect(ramBlack, epd.buffer, r); err != nil {
+			epd.dirty = true // the frame buffer is now ahead of the device
+			return err
+		}
+	}
+	return epd.turnOnDisplay()
+}
+
+// merge aligns the regions to byte boundaries on the x-axis and merges the ones that overlap or share an edge
+// into their bounding box, until no more regions can be merged
+func merge(regions []image.Rectangle) []image.Rectangle {
+	var out = make([]image.Rectangle, 0, len(regions))
+	for _, r := range regions {
+		out = append(out, image.Rect(r.Min.X&^7, r.Min.Y, (r.Max.X+7)&^7, r.Max.Y))
+	}
+
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(out) && !merged; i++ {
+			for j := i + 1; j < len(out); j++ {
+				if touches(out[i], out[j]) {
+					out[i] = out[i].Union(out[j])
+					out = append(out[:j], out[j+1:]...)
+					merged = true
+					break
+				}
+			}
+		}
+	}
+	return out
+}
+
+// touches reports whether the two regions overlap or share (a part of) an edge
+func touches(a, b image.Rectangle) bool {
+	var xs = a.Min.X < b.Max.X && b.Min.X < a.Max.X // overlapping columns
+	var ys = a.Min.Y < b.Max.Y && b.Min.Y < a.Max.Y // overlapping rows
+	var xt = a.Min.X <= b.Max.X && b.Min.X <= a.Max.X
+	var yt = a.Min.Y <= b.Max.Y && b.Min.Y <= a.Max.Y
+	return (xs && yt) || (ys && xt)
+}
@@ -0,0 +1,33 @@
+package epd
+
+import (
+	"bytes"
+	"image"
+	"testing"
+)
+
+func TestBatchPower(t *testing.T) {
+	var epd, rec = newTestEPD(16, 2)
+	if err := epd.Mode(PartialUpdate); err != nil {
+		t.Fatalf("Mode() failed: %v", err)
+	}
+	rec.Reset()
+
+	if err := epd.Begin(); err != nil {
+		t.Fatalf("Begin() failed: %v", err)
+	}
+	for _, r := range []image.Rectangle{image.Rect(0, 0, 8, 1), image.Rect(8, 1, 16, 2)} {
+		if err := epd.DrawRegion(gray(8, 1, image.Pt(0, 0)), r); err != nil {
+			t.Fatalf("DrawRegion() failed: %v", err)
+		}
+	}
+	if err := epd.End(); err != nil {
+		t.Fatalf("End() failed: %v", err)
+	}
+
+	// powered up by Begin, refreshed once without powering up or down, and powered down by End
+	var want = []byte{powerUp, 0x04, powerDown}
+	if got := written(rec, 0x22); !bytes.Equal(got, want) {
+		t.Errorf("batch sent DISPLAY_UPDATE_CONTROL_2 sequences % X, want % X", got, want)
+	}
+}
@@ -0,0 +1,78 @@
+package epd
+
+import (
+	"context"
+	"time"
+)
+
+// pollIntervals are the default intervals at which the busy line is polled in each mode, when the pin doesn't
+// support edge detection; partial updates complete much faster than full updates and so are polled more often
+var pollIntervals = map[Mode]time.Duration{
+	FullUpdate:    200 * time.Millisecond,
+	PartialUpdate: 20 * time.Millisecond,
+}
+
+// pollInterval returns the interval at which the busy line is polled in the current mode (see PollIntervals)
+func (epd *EPD) pollInterval() time.Duration {
+	if d, ok := epd.PollIntervals[epd.mode]; ok && d > 0 {
+		return d
+	}
+	return pollIntervals[epd.mode]
+}
+
+// EdgeReadablePin is a ReadablePin that can wait for the level of the pin to change
+// Backends supporting interrupt-driven GPIO can implement it to avoid polling the busy line.
+type EdgeReadablePin interface {
+	ReadablePin
+
+	// WaitForEdge blocks until the level of the pin changes or the timeout elapses, returning false on timeout
+	WaitForEdge(timeout time.Duration) bool
+}
+
+// BusyEvents returns a channel that receives the state of the busy line (true when busy) every time it changes
+// It uses edge detection if the busy pin supports it (see EdgeReadablePin) and falls back to polling otherwise.
+// The channel is closed once the context is cancelled.
+//
+// The poll interval (of the mode the device is in), the busy level and the Clock are captured when BusyEvents
+// is called, after which only the busy pin is read. This makes it safe to keep calling the driver's methods
+// (such as Mode and Draw) while the channel is live, as long as the pin itself can be read concurrently.
+func (epd *EPD) BusyEvents(ctx context.Context) <-chan bool {
+	var pin, level, clock, interval = epd.busy, epd.busyLevel, epd.clock(), epd.pollInterval()
+	var last = pin.Read() == level
+
+	var events = make(chan bool)
+	go func() {
+		defer close(events)
+
+		for ctx.Err() == nil {
+			waitBusy(pin, clock, interval)
+			if busy := pin.Read() == level; busy != last {
+				select {
+				case events <- busy:
+				case <-ctx.Done():
+				}
+				last = busy
+			}
+		}
+	}()
+	return events
+}
+
+// isBusy reports whether the busy line is currently signalling that the device is busy
+func (epd *EPD) isBusy() bool {
+	return epd.busy.Read() == epd.busyLevel
+}
+
+// waitBusy waits for the busy line to change, or for at most d if the pin doesn't support edge detection
+func (epd *EPD) waitBusy(d time.Duration) {
+	waitBusy(epd.busy, epd.clock(), d)
+}
+
+// waitBusy waits for the level of the pin to change, or sleeps for d if it doesn't support edge detection
+func waitBusy(pin ReadablePin, clock Clock, d time.Duration) {
+	if edge, ok := pin.(EdgeReadablePin); ok {
+		edge.WaitForEdge(d)
+		return
+	}
+	clock.Sleep(d)
+}
@@ -0,0 +1,116 @@
+package epd
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// levelPin is a pin whose level can be changed concurrently with it being read
+type levelPin struct{ level uint32 }
+
+func (pin *levelPin) Read() uint8 { return uint8(atomic.LoadUint32(&pin.level)) }
+
+func (pin *levelPin) set(level uint8) { atomic.StoreUint32(&pin.level, uint32(level)) }
+
+func TestBusyEvents(t *testing.T) {
+	var busy = &levelPin{}
+	var epd = NewWithInterface(nop{}, busy, nop{})
+	epd.Width, epd.Height = 16, 2
+	epd.ResetFunc = func(WriteablePin) {}
+	epd.PollIntervals = map[Mode]time.Duration{FullUpdate: time.Millisecond, PartialUpdate: time.Millisecond}
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	var events = epd.BusyEvents(ctx)
+
+	// the driver must remain usable while the channel is live (run with -race to check for data races)
+	var done = make(chan error)
+	go func() {
+		var err = epd.Mode(PartialUpdate)
+		if err == nil {
+			err = epd.Draw(gray(16, 2))
+		}
+		done <- err
+	}()
+	if err := <-done; err != nil {
+		t.Fatalf("drawing whilst receiving busy events failed: %v", err)
+	}
+
+	busy.set(1)
+	select {
+	case state := <-events:
+		if !state {
+			t.Errorf("BusyEvents() sent %v once the device got busy, want true", state)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("BusyEvents() didn't report the device getting busy")
+	}
+}
+
+// newBusyEPD returns a test driver whose busy line is stuck signalling that the device is busy
+func newBusyEPD() (*EPD, *fakeClock) {
+	var epd, _ = newTestEPD(16, 2)
+	epd.busy = &levelPin{level: uint32(epd.busyLevel)}
+	epd.BusyTimeout = time.Second
+	return epd, epd.Clock.(*fakeClock)
+}
+
+func TestBusyTimeout(t *testing.T) {
+	var epd, clock = newBusyEPD()
+	var start = clock.Now()
+
+	if err := epd.idle(); err != ErrBusyTimeout {
+		t.Fatalf("idle() returned %v, want ErrBusyTimeout", err)
+	}
+	// the timeout is only noticed at the first poll after it elapses
+	if waited, max := clock.Now().Sub(start), epd.BusyTimeout+epd.pollInterval(); waited <= epd.BusyTimeout || waited > max {
+		t.Errorf("idle() gave up after %v, want more than %v and at most %v", waited, epd.BusyTimeout, max)
+	}
+}
+
+func TestBusyRetries(t *testing.T) {
+	var epd, clock = newBusyEPD()
+	epd.BusyRetries, epd.BusyBackoff = 3, 10*time.Second // longer than any poll interval, to tell them apart
+
+	if err := epd.idle(); err != ErrBusyTimeout {
+		t.Fatalf("idle() returned %v, want ErrBusyTimeout", err)
+	}
+
+	var backoffs []time.Duration
+	for _, d := range clock.sleeps {
+		if d >= epd.BusyBackoff {
+			backoffs = append(backoffs, d)
+		}
+	}
+	var want = []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second}
+	if !reflect.DeepEqual(backoffs, want) {
+		t.Errorf("idle() backed off for %v, want %v", backoffs, want)
+	}
+}
+
+func TestWatchdogReset(t *testing.T) {
+	var epd, _ = newBusyEPD()
+	var resets int
+	epd.ResetFunc = func(WriteablePin) { resets++ }
+	epd.mode = PartialUpdate
+
+	epd.WatchdogReset = false
+	if err := epd.idle(); err != ErrBusyTimeout || resets != 0 {
+		t.Fatalf("idle() without WatchdogReset returned %v after %d resets, want ErrBusyTimeout and none", err, resets)
+	}
+
+	// the re-initialisation times out as well, but mustn't trigger the watchdog again
+	epd.WatchdogReset = true
+	if err := epd.idle(); err != ErrBusyTimeout {
+		t.Fatalf("idle() returned %v, want ErrBusyTimeout", err)
+	}
+	if resets != 1 {
+		t.Errorf("watchdog reset the device %d times, want once", resets)
+	}
+	if epd.mode != PartialUpdate || epd.recovering {
+		t.Errorf("watchdog left the device in mode %v (recovering: %t), want %v", epd.mode, epd.recovering, PartialUpdate)
+	}
+}
@@ -0,0 +1,52 @@
+package epd
+
+import (
+	"fmt"
+	"image"
+
+	"golang.org/x/image/font/basicfont"
+)
+
+// calibrationSteps is the number of candidate thresholds shown by Calibrate
+const calibrationSteps = 8
+
+// Calibrate helps picking a Threshold suitable for the panel and the lighting it's viewed in
+//
+// It renders a threshold sweep onto the display: a band for each candidate threshold, labeled with its index,
+// showing a gray ramp (from black on the left to white on the right) as it would be rendered using that threshold.
+// The choose function is then called with the candidates and must return the index of the preferred one
+// (for example as picked by the user or using a light sensor), which is stored as the driver's Threshold.
+// A negative index leaves the Threshold unchanged.
+func (epd *EPD) Calibrate(choose func(candidates []float64) int) error {
+	var candidates = make([]float64, calibrationSteps)
+	for i := range candidates {
+		candidates[i] = 0xFFFF * (float64(i) + 0.5) / calibrationSteps
+	}
+
+	var face = basicfont.Face7x13
+	var label = MeasureText("0 ", face)
+	var band = epd.Height / calibrationSteps
+
+	epd.fill(epd.bounds(), false)
+	for i, t := range candidates {
+		var y = i * band
+		epd.DrawText(fmt.Sprint(i), face, image.Pt(0, y+(band-label.Y)/2))
+
+		var ramp = image.Rect(label.X, y+2, epd.Width, y+band-2)
+		for x := ramp.Min.X; x < ramp.Max.X; x++ {
+			var v = uint32(0xFFFF * (x - ramp.Min.X) / (ramp.Dx() - 1))
+			if brightness(v, v, v) <= t {
+				epd.fill(image.Rect(x, ramp.Min.Y, x+1, ramp.Max.Y), true)
+			}
+		}
+	}
+
+	if err := epd.Commit(); err != nil {
+		return err
+	}
+
+	if i := choose(candidates); i >= 0 && i < len(candidates) {
+		epd.Threshold = candidates[i]
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package epd
+
+import (
+	"image"
+	"image/color"
+)
+
+// Caret is a caret (or selection highlight) drawn over a region of the display for interactive use
+//
+// Showing the caret inverts the pixels in its region, and hiding it restores the content that was underneath,
+// as recorded in the driver's frame buffer. Each change is rendered using DrawRegion, so the device is expected
+// to be in PartialUpdate mode. Nothing else should draw over the caret's region while it is visible.
+type Caret struct {
+	epd     *EPD
+	r       image.Rectangle
+	visible bool
+	under   []bool // darkness of the pixels underneath the caret, recorded when it was shown
+}
+
+// NewCaret creates a new (hidden) Caret covering the region r of the display
+func NewCaret(epd *EPD, r image.Rectangle) (*Caret, error) {
+	if r.Empty() || !r.In(epd.bounds()) {
+		return nil, ErrInvalidImageSize
+	}
+	return &Caret{epd: epd, r: r}, nil
+}
+
+// Visible reports whether the caret is currently shown
+func (c *Caret) Visible() bool { return c.visible }
+
+// Show draws the caret onto the display, if it isn't already visible
+func (c *Caret) Show() error {
+	if c.visible {
+		return nil
+	}
+
+	c.under = c.under[:0]
+	for y := c.r.Min.Y; y < c.r.Max.Y; y++ {
+		for x := c.r.Min.X; x < c.r.Max.X; x++ {
+			c.under = append(c.under, c.epd.pixel(x, y))
+		}
+	}
+	c.visible = true // the frame buffer holds the caret even if rendering it fails
+	return c.render(true)
+}
+
+// Hide erases the caret from the display by restoring the content underneath it, if it's visible
+func (c *Caret) Hide() error {
+	if !c.visible {
+		return nil
+	}
+	c.visible = false
+	return c.render(false)
+}
+
+// Toggle shows the caret if it's hidden and hides it otherwise; call it periodically to make the caret blink
+func (c *Caret) Toggle() error {
+	if c.visible {
+		return c.Hide()
+	}
+	return c.Show()
+}
+
+// Move moves the caret to the region r, erasing it from its current position first if it's visible
+// The caret keeps its visibility, i.e. a visible caret is drawn at its new position.
+func (c *Caret) Move(r image.Rectangle) error {
+	if r.Empty() || !r.In(c.epd.bounds()) {
+		return ErrInvalidImageSize
+	}
+
+	var visible = c.visible
+	if err := c.Hide(); err != nil {
+		return err
+	}
+	c.r = r
+	if visible {
+		return c.Show()
+	}
+	return nil
+}
+
+// render draws the region of the caret onto the display, either inverted (shown) or with the content underneath
+func (c *Caret) render(inverted bool) error {
+	var img = image.NewGray(c.r)
+	for i, y := 0, c.r.Min.Y; y < c.r.Max.Y; y++ {
+		for x := c.r.Min.X; x < c.r.Max.X; x, i = x+1, i+1 {
+			if c.under[i] == inverted {
+				img.SetGray(x, y, color.Gray{Y: 0xFF})
+			}
+		}
+	}
+	return c.epd.DrawRegion(img, c.r)
+}
@@ -0,0 +1,59 @@
+package epd
+
+import (
+	"image"
+	"image/color"
+)
+
+// DrawCentered renders the given image at the center of the display over the Background
+// The image can be of any size as long as it fits within the display (after it's run through the Pipeline).
+//
+// Only the region of the display covered by the image is transmitted, the margins being painted in the Background
+// by the first frame that needs them (ie. when the display showed something else). As the rest of the display is
+// left untouched, it's best used in PartialUpdate mode.
+func (epd *EPD) DrawCentered(img image.Image) error {
+	img = epd.preprocess(img)
+	var size = img.Bounds().Size()
+	if size.X > epd.Width || size.Y > epd.Height {
+		return ErrInvalidImageSize
+	}
+
+	var min = image.Pt((epd.Width-size.X)/2, (epd.Height-size.Y)/2)
+	var r = image.Rectangle{Min: min, Max: min.Add(size)}
+	var buf = epd.pack(placed{img, r, epd.bounds(), epd.background()})
+	epd.stamp(buf)
+	if epd.target != None || epd.buffer == nil || epd.dirty {
+		return epd.show(buf) // nothing on the display to keep; transmit the whole frame
+	}
+	if epd.identical(buf) {
+		return nil // nothing has changed
+	}
+
+	// transmit the image's region along with anything outside of it that differs (such as the margins)
+	if err := epd.writeRect(ramBlack, buf, r.Union(epd.changed(epd.buffer, buf))); err != nil {
+		return err
+	}
+	epd.buffer = buf
+	return epd.turnOnDisplay()
+}
+
+// placed is an image with the src image placed at the region r of a canvas with the given bounds,
+// filled with the background color
+type placed struct {
+	src        image.Image
+	r          image.Rectangle
+	bounds     image.Rectangle
+	background color.Color
+}
+
+func (p placed) ColorModel() color.Model { return p.src.ColorModel() }
+
+func (p placed) Bounds() image.Rectangle { return p.bounds }
+
+func (p placed) At(x, y int) color.Color {
+	if !(image.Point{X: x, Y: y}.In(p.r)) {
+		return p.background
+	}
+	var off = p.src.Bounds().Min.Sub(p.r.Min)
+	return p.src.At(x+off.X, y+off.Y)
+}
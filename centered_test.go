@@ -0,0 +1,44 @@
+package epd
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestDrawCentered(t *testing.T) {
+	var epd, rec = newTestEPD(32, 4)
+	epd.Background = color.Black
+
+	// a 16x2 image centered on a 32x4 display leaves 8 pixels of margin on either side and a row above and below
+	var frames = []struct {
+		name     string
+		img      image.Image
+		ram      []byte // bytes expected to be written into the RAM
+		snapshot []byte
+	}{
+		{
+			"first frame", gray(16, 2),
+			[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0x00, 0x00, 0xFF, 0xFF, 0x00, 0x00, 0x00, 0x00, 0x00},
+			[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0x00, 0x00, 0xFF, 0xFF, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			"next frame", gray(16, 2, image.Pt(0, 0)),
+			[]byte{0x7F, 0xFF, 0xFF, 0xFF}, // only the image's region
+			[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x7F, 0xFF, 0x00, 0x00, 0xFF, 0xFF, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+	}
+	for _, f := range frames {
+		rec.Reset()
+		if err := epd.DrawCentered(f.img); err != nil {
+			t.Fatalf("DrawCentered() of the %s failed: %v", f.name, err)
+		}
+		if ram := written(rec, ramBlack); !bytes.Equal(ram, f.ram) {
+			t.Errorf("DrawCentered() of the %s wrote % X into RAM, want % X", f.name, ram, f.ram)
+		}
+		if snapshot := epd.Snapshot(); !bytes.Equal(snapshot, f.snapshot) {
+			t.Errorf("Snapshot() after the %s = % X, want % X", f.name, snapshot, f.snapshot)
+		}
+	}
+}
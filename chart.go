@@ -0,0 +1,58 @@
+package epd
+
+import (
+	"image"
+	"math"
+)
+
+// DrawSparkline draws the series of values as a connected line onto the region r of the frame buffer
+//
+// The region is cleared first and the values are scaled so that the minimum and maximum of the series
+// touch the bottom and top edges of r respectively. The values are spread evenly along the x-axis.
+// NaN and infinite values leave a gap in the line. The chart is only rendered onto the display once Commit is called.
+func (epd *EPD) DrawSparkline(values []float64, r image.Rectangle) {
+	epd.fill(r, false)
+	if r.Empty() || len(values) == 0 {
+		return
+	}
+
+	var min, max = math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !invalid(v) {
+			min, max = math.Min(min, v), math.Max(max, v)
+		}
+	}
+
+	var w, h = r.Dx() - 1, r.Dy() - 1
+	var point = func(i int, v float64) image.Point {
+		var x, y = r.Min.X + w/2, r.Min.Y + h/2 // single values and flat series are centered
+		if len(values) > 1 {
+			x = r.Min.X + i*w/(len(values)-1)
+		}
+		if max > min {
+			y = r.Max.Y - 1 - int(math.Round((v-min)/(max-min)*float64(h)))
+		}
+		return image.Point{X: x, Y: y}
+	}
+
+	var prev *image.Point
+	for i, v := range values {
+		if invalid(v) {
+			prev = nil
+			continue
+		}
+
+		var p = point(i, v)
+		if prev == nil {
+			epd.line(p, p, r)
+		} else {
+			epd.line(*prev, p, r)
+		}
+		prev = &p
+	}
+}
+
+// invalid reports whether v can't be plotted
+func invalid(v float64) bool {
+	return math.IsNaN(v) || math.IsInf(v, 0)
+}
@@ -0,0 +1,42 @@
+package epd
+
+import "image"
+
+// DrawClipped renders the pixels of the given image where the clip mask is set onto the display, leaving the rest
+// of the display untouched. A pixel of the mask is set if it is light (for grayscale masks) or opaque (for alpha
+// masks), making it a hard stencil rather than an alpha blend.
+//
+// Both the image and the mask must be of the size of the display; only the image is run through the Pipeline.
+// Only the region covering the set pixels of the mask is transmitted, so it's best used in PartialUpdate mode.
+// The rest of the display should have been drawn by the driver beforehand (see DrawRegion).
+func (epd *EPD) DrawClipped(img, clip image.Image) error {
+	img = epd.preprocess(img)
+	if !epd.Fits(img) || !epd.Fits(clip) {
+		return ErrInvalidImageSize
+	}
+
+	var src, mask = epd.origin(img), epd.origin(clip)
+	var r image.Rectangle
+	for y := 0; y < epd.Height; y++ {
+		for x := 0; x < epd.Width; x++ {
+			if luminance(clip.At(mask.X+x, mask.Y+y)) < 0x80 {
+				continue
+			}
+			epd.setpixel(x, y, epd.dark(img.At(src.X+x, src.Y+y)))
+			r = r.Union(image.Rect(x, y, x+1, y+1))
+		}
+	}
+
+	if r.Empty() {
+		return nil // nothing to draw
+	}
+	return epd.update(r)
+}
+
+// origin returns the point of the image that maps to the top-left corner of the display
+func (epd *EPD) origin(img image.Image) image.Point {
+	if _, uniform := img.(*image.Uniform); uniform {
+		return image.Point{}
+	}
+	return img.Bounds().Min
+}
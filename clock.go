@@ -0,0 +1,75 @@
+package epd
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the source of time used by the driver for its delays (such as the reset pulse and polling the busy line)
+// and for the busy timeout. Substituting it (see EPD.Clock) allows the timing to be controlled, eg. in tests.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// Sleep pauses the calling goroutine for at least the duration d
+	Sleep(d time.Duration)
+}
+
+// ContextClock is a Clock whose sleeps can be cut short, used for the long pauses (such as the ones in Slideshow)
+// that are interrupted by cancelling a context
+type ContextClock interface {
+	Clock
+
+	// SleepContext pauses the calling goroutine for at least the duration d, or until the context is cancelled
+	// in which case it returns the context's error
+	SleepContext(ctx context.Context, d time.Duration) error
+}
+
+// realClock is the Clock backed by the time package, used if no Clock is set
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+func (realClock) SleepContext(ctx context.Context, d time.Duration) error {
+	var timer = time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// clock returns the driver's Clock
+func (epd *EPD) clock() Clock {
+	if epd.Clock != nil {
+		return epd.Clock
+	}
+	return realClock{}
+}
+
+// sleepContext pauses for the duration d on the driver's Clock, returning the context's error once it's cancelled
+// A Clock that isn't a ContextClock can't be interrupted, so its sleep is left to run out in the background.
+func (epd *EPD) sleepContext(ctx context.Context, d time.Duration) error {
+	var clock = epd.clock()
+	if c, ok := clock.(ContextClock); ok {
+		return c.SleepContext(ctx, d)
+	}
+
+	var slept = make(chan struct{})
+	go func() {
+		defer close(slept)
+		clock.Sleep(d)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-slept:
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package epd
+
+import (
+	"image"
+	"image/color"
+	"sort"
+)
+
+// Compositor manages a set of named layers, each an image placed at a position on the display,
+// and flattens them onto the display with layers of higher z-order covering the ones below.
+//
+// Fully transparent pixels of a layer let the layers below show through; areas not covered by any layer are
+// painted in the display's Background. Updating a layer only re-renders the region it covers (and the region
+// it used to cover) using DrawRegion, so the device is expected to be in PartialUpdate mode.
+type Compositor struct {
+	epd    *EPD
+	layers []*layer // sorted by z-order, bottom-most first
+}
+
+// layer is a single image placed on the compositor
+type layer struct {
+	name string
+	img  image.Image
+	r    image.Rectangle // region of the display covered by the layer
+	z    int
+}
+
+// NewCompositor creates a new compositor rendering onto the given display
+func NewCompositor(epd *EPD) *Compositor {
+	return &Compositor{epd: epd}
+}
+
+// Set adds a layer (or updates the existing one with the same name) showing img with its top-left corner at pt
+// and re-renders the affected region of the display.
+func (c *Compositor) Set(name string, img image.Image, pt image.Point, z int) error {
+	var l = &layer{name: name, img: img, r: image.Rectangle{Min: pt, Max: pt.Add(img.Bounds().Size())}, z: z}
+
+	var dirty = l.r
+	if i := c.index(name); i >= 0 {
+		dirty = dirty.Union(c.layers[i].r)
+		c.layers = append(c.layers[:i], c.layers[i+1:]...)
+	}
+	c.layers = append(c.layers, l)
+	sort.SliceStable(c.layers, func(i, j int) bool { return c.layers[i].z < c.layers[j].z })
+
+	return c.render(dirty)
+}
+
+// Remove removes the named layer, if it exists, and re-renders the region it used to cover
+func (c *Compositor) Remove(name string) error {
+	var i = c.index(name)
+	if i < 0 {
+		return nil
+	}
+
+	var r = c.layers[i].r
+	c.layers = append(c.layers[:i], c.layers[i+1:]...)
+	return c.render(r)
+}
+
+// Render flattens all the layers and renders them onto the whole display
+// Like Set and Remove, it renders using DrawRegion so that all of them produce the same pixels for the same layers;
+// the Pipeline, FlipH / FlipV and PackRow used by Draw are not applied.
+func (c *Compositor) Render() error {
+	return c.render(c.epd.bounds())
+}
+
+// render flattens the layers covering the region r and renders them onto that region of the display
+func (c *Compositor) render(r image.Rectangle) error {
+	if r = r.Intersect(c.epd.bounds()); r.Empty() {
+		return nil
+	}
+	return c.epd.DrawRegion(composite{c, r}, r)
+}
+
+// index returns the position of the named layer in the stack, or -1 if there's no such layer
+func (c *Compositor) index(name string) int {
+	for i, l := range c.layers {
+		if l.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// composite is the flattened view of a compositor's layers over the region r of the display
+type composite struct {
+	*Compositor
+	r image.Rectangle
+}
+
+func (cm composite) ColorModel() color.Model { return color.RGBAModel }
+
+func (cm composite) Bounds() image.Rectangle { return cm.r }
+
+func (cm composite) At(x, y int) color.Color {
+	var pt = image.Point{X: x, Y: y}
+	for i := len(cm.layers) - 1; i >= 0; i-- {
+		var l = cm.layers[i]
+		if !pt.In(l.r) {
+			continue
+		}
+
+		var src = pt.Add(l.img.Bounds().Min.Sub(l.r.Min))
+		var c = l.img.At(src.X, src.Y)
+		if _, _, _, a := c.RGBA(); a != 0 {
+			return c
+		}
+	}
+	return cm.epd.background()
+}
@@ -0,0 +1,34 @@
+package epd
+
+import (
+	"bytes"
+	"image"
+	"testing"
+)
+
+func TestCompositorRender(t *testing.T) {
+	var epd, rec = newTestEPD(16, 1)
+	epd.FlipH = true // only applied by Draw, and so must not make Render differ from Set
+	if err := epd.Mode(PartialUpdate); err != nil {
+		t.Fatalf("Mode() failed: %v", err)
+	}
+
+	var c = NewCompositor(epd)
+	rec.Reset()
+	if err := c.Set("dot", black(1, 1), image.Pt(0, 0), 0); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+	var set = written(rec, 0x24)
+
+	rec.Reset()
+	if err := c.Render(); err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	// Set only transmits the byte covering the layer
+	if want := []byte{0x7F}; !bytes.Equal(set, want) {
+		t.Errorf("Set() wrote % X, want % X", set, want)
+	}
+	if got, want := written(rec, 0x24), []byte{0x7F, 0xFF}; !bytes.Equal(got, want) {
+		t.Errorf("Render() wrote % X, want % X", got, want)
+	}
+}
@@ -0,0 +1,47 @@
+package epd
+
+import "image"
+
+// DrawDiff renders the given image onto the display, transmitting only the part of the frame that has changed
+// since the last frame drawn by the driver. It is meant to be used in PartialUpdate mode.
+// Like Draw, the image is run through the Pipeline first.
+//
+// The changes are detected by comparing against the driver's frame buffer. If there's no previous frame
+// (or the buffer holds changes not yet written to the device) it falls back to a complete Draw.
+// If nothing has changed the display isn't refreshed at all.
+func (epd *EPD) DrawDiff(img image.Image) error {
+	img = epd.preprocess(img)
+	if !epd.Fits(img) {
+		return ErrInvalidImageSize
+	}
+	if epd.buffer == nil || epd.dirty {
+		return epd.render(img)
+	}
+
+	var buf = epd.pack(img)
+	epd.stamp(buf)
+	var r = epd.changed(epd.buffer, buf)
+	if r.Empty() {
+		return nil
+	}
+
+	if err := epd.writeRect(ramBlack, buf, r); err != nil {
+		return err
+	}
+	epd.buffer = buf
+	return epd.turnOnDisplay()
+}
+
+// changed returns the smallest region covering all the bytes that differ between the two packed frames
+// The returned region is aligned to byte boundaries on the x-axis, and is empty if the frames are identical.
+func (epd *EPD) changed(a, b []byte) image.Rectangle {
+	var stride = epd.stride()
+	var r image.Rectangle
+	for i := range a {
+		if a[i] != b[i] {
+			var x, y = (i % stride) * 8, i / stride
+			r = r.Union(image.Rect(x, y, x+8, y+1))
+		}
+	}
+	return r.Intersect(epd.bounds())
+}
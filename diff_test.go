@@ -0,0 +1,24 @@
+package epd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDrawDiffPipeline(t *testing.T) {
+	var epd, rec = newTestEPD(16, 2)
+	epd.Pipeline = Pipeline{Scale(16, 2), Invert()}
+
+	// every frame, not just the first, must be run through the pipeline (which also resizes it to fit)
+	for i := 0; i < 2; i++ {
+		if err := epd.DrawDiff(gray(32, 4)); err != nil {
+			t.Fatalf("DrawDiff() #%d failed: %v", i, err)
+		}
+		if snapshot := epd.Snapshot(); !bytes.Equal(snapshot, []byte{0x00, 0x00, 0x00, 0x00}) {
+			t.Fatalf("Snapshot() after DrawDiff() #%d = % X, want inverted (all black) frame", i, snapshot)
+		}
+	}
+	if n := len(written(rec, ramBlack)); n != 4 {
+		t.Errorf("DrawDiff() wrote %d bytes into RAM, want 4 (the unchanged second frame is skipped)", n)
+	}
+}
@@ -0,0 +1,80 @@
+package epd
+
+import "image"
+
+// segments maps the characters supported by DrawDigits to their lit segments, with bits 0 - 6 being
+// the segments a - g: a is the top one, going clockwise through b - f, and g is the middle one
+var segments = map[rune]uint8{
+	'0': 0x3F, '1': 0x06, '2': 0x5B, '3': 0x4F, '4': 0x66,
+	'5': 0x6D, '6': 0x7D, '7': 0x07, '8': 0x7F, '9': 0x6F,
+	'-': 0x40, '_': 0x08, '°': 0x63, ' ': 0x00,
+}
+
+// DrawDigits draws the string s as seven-segment digits onto the display, with (x, y) as the top-left corner
+//
+// Each digit is size pixels wide and twice as tall, with segments size/8 pixels thick (at least 1), and
+// the whole of its cell is redrawn.
+// Besides digits, it supports colons, periods, spaces, and the '-', '_' and '°' symbols; other characters are
+// drawn as spaces. Only the region covering the pixels that changed is rendered, so redrawing a readout in
+// PartialUpdate mode only refreshes the changed segments.
+func (epd *EPD) DrawDigits(s string, x, y, size int) error {
+	var t = size / 8
+	if t < 1 {
+		t = 1
+	}
+	var w, h = size, 2 * size
+
+	var changed image.Rectangle
+	var paint = func(cell image.Rectangle, lit []image.Rectangle) {
+		var r = cell.Intersect(epd.bounds())
+		for py := r.Min.Y; py < r.Max.Y; py++ {
+			for px := r.Min.X; px < r.Max.X; px++ {
+				var p, dark = image.Pt(px, py), false
+				for _, l := range lit {
+					dark = dark || p.In(l.Add(cell.Min))
+				}
+				if epd.pixel(px, py) != dark {
+					epd.setpixel(px, py, dark)
+					changed = changed.Union(image.Rectangle{Min: p, Max: p.Add(image.Pt(1, 1))})
+				}
+			}
+		}
+	}
+
+	var bars = []image.Rectangle{
+		image.Rect(t, 0, w-t, t),               // a
+		image.Rect(w-t, t, w, h/2),             // b
+		image.Rect(w-t, h/2, w, h-t),           // c
+		image.Rect(t, h-t, w-t, h),             // d
+		image.Rect(0, h/2, t, h-t),             // e
+		image.Rect(0, t, t, h/2),               // f
+		image.Rect(t, h/2-t/2, w-t, h/2-t/2+t), // g
+	}
+
+	for _, c := range s {
+		var cell = image.Rect(x, y, x+w, y+h)
+		var lit []image.Rectangle
+		switch c {
+		case ':':
+			cell.Max.X = x + 3*t
+			lit = []image.Rectangle{image.Rect(t, h/3-t/2, 2*t, h/3-t/2+t), image.Rect(t, 2*h/3-t/2, 2*t, 2*h/3-t/2+t)}
+		case '.':
+			cell.Max.X = x + 3*t
+			lit = []image.Rectangle{image.Rect(t, h-t, 2*t, h)}
+		default:
+			for i, bar := range bars {
+				if segments[c]&(1<<i) != 0 {
+					lit = append(lit, bar)
+				}
+			}
+		}
+
+		paint(cell, lit)
+		x = cell.Max.X + 2*t
+	}
+
+	if changed.Empty() {
+		return nil
+	}
+	return epd.update(changed)
+}
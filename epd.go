@@ -1,17 +1,28 @@
 // Package epd provides driver for Waveshare's E-paper e-ink display
-package epd  // import "go.riyazali.net/epd"
+package epd // import "go.riyazali.net/epd"
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"image"
 	"image/color"
+	"image/draw"
+	"log"
 	"math"
+	"sync"
 	"time"
 )
 
 // ErrInvalidImageSize is returned if the given image bounds doesn't fit into display bounds
 var ErrInvalidImageSize = errors.New("invalid image size")
 
+// ErrInvalidBufferSize is returned if the given buffer's length doesn't match the display's packed frame size
+var ErrInvalidBufferSize = errors.New("invalid buffer size")
+
+// ErrBusyTimeout is returned if the device doesn't get into idle state within the configured BusyTimeout
+var ErrBusyTimeout = errors.New("timed out waiting for device")
+
 // LookupTable defines a type holding the instruction lookup table
 // This lookup table is used by the device when performing refreshes
 type Mode uint8
@@ -39,6 +50,12 @@ const (
 	PartialUpdate
 )
 
+// commands used to write into the device's RAM banks
+const (
+	ramBlack byte = 0x24 // WRITE_RAM; holds the black/white plane
+	ramRed   byte = 0x26 // WRITE_RAM_RED; holds the red plane on tri-color panels
+)
+
 // fullUpdate is a lookup table used whilst in full update mode
 var fullUpdate = []byte{
 	0x50, 0xAA, 0x55, 0xAA, 0x11, 0x00,
@@ -63,52 +80,339 @@ type EPD struct {
 	Height int
 	Width  int
 
+	// Revision selects the revision of the panel (and its controller) and hence the initialisation sequence used
+	Revision Revision
+
+	// waveform tuning parameters applied by Mode (only used by V1 panels)
+	// these affect the refresh speed and quality, and might need adjusting alongside the lookup table
+	DummyLinePeriod byte // value for SET_DUMMY_LINE_PERIOD (0x3A)
+	GateTime        byte // value for SET_GATE_TIME (0x3B)
+
+	// TemperatureProfiles, if set, replace the booster soft start and gate time values used by Mode depending on
+	// the ambient temperature reported by Temperature, which is supplied by the caller (only used by V1 panels);
+	// see TemperatureProfile
+	TemperatureProfiles []TemperatureProfile
+	Temperature         func() (float64, error)
+
+	// Waveforms holds the waveforms uploaded into V2 panels in each mode, each either just the 153 bytes lookup table
+	// or followed by the end option, gate voltage, source voltages and VCOM values (159 bytes in total).
+	// A mode missing from the map makes the panel load its stock waveform from OTP instead, which is often better
+	// tuned for the panel. By default, full updates load the waveform from OTP and partial updates use the driver's.
+	Waveforms map[Mode][]byte
+
+	// GateScan is the last byte of DRIVER_OUTPUT_CONTROL (0x01) sent by Mode, selecting the gate scanning order
+	// bit 0 (TB) reverses the scanning direction, bit 1 (SM) interlaces the gates and bit 2 (GD) swaps the first gate;
+	// setting it is often the proper fix for vertically mirrored or shifted output on some panels
+	GateScan byte
+
+	// GhostGuardEvery, if set, makes the driver run a DrawClean cycle before every Nth partial update
+	// to proactively prevent ghosting from building up on displays that are only partially updated
+	GhostGuardEvery int
+
+	// SPIHz is the clock speed of the SPI bus, if known
+	// it is only informational and used to estimate throughput dependent timings (see FrameTransmitTime)
+	SPIHz int
+
+	// BusyTimeout is the maximum duration to wait for the device to get into idle state
+	// a zero value (the default) waits forever
+	BusyTimeout time.Duration
+
+	// BusyRetries is the number of times the wait is retried once the busy timeout is exceeded before giving up
+	// (and resetting the device, see WatchdogReset); BusyBackoff is the pause before the first retry, doubling
+	// before each subsequent one. On flaky hardware a transient stall of the busy line often clears on a second wait.
+	BusyRetries int
+	BusyBackoff time.Duration
+
+	// CursorWait makes the driver wait for the device to get into idle state after every time it sets the RAM
+	// address counter (ie. before each row written into the RAM), like the original reference driver does.
+	// Setting the address doesn't keep the device busy, so this only adds latency and is off by default.
+	CursorWait bool
+
+	// Progressive makes drawing a frame reveal it from top to bottom, in bands that are each refreshed as soon as
+	// they are written. It takes longer in total but shows progress early; meant to be used in PartialUpdate mode.
+	Progressive bool
+
+	// SettleDelay is an additional delay after every refresh completes (once the device is idle) for the panel
+	// to settle, before the call returns and OnAfterRefresh is called; useful if the power is cut right after a draw
+	SettleDelay time.Duration
+
+	// UpdateBudget, if set, is the number of refreshes (full and partial) the panel is rated for
+	// a warning is logged once the refreshes counted by UpdateStats exceed it
+	UpdateBudget uint64
+
+	// PollIntervals, if set, overrides the intervals at which the busy line is polled in each mode (when the pin
+	// doesn't support edge detection); by default it's polled every 200ms in FullUpdate and every 20ms in PartialUpdate
+	PollIntervals map[Mode]time.Duration
+
+	// WatchdogReset makes the driver perform a hardware reset and re-initialise the device (in its last mode)
+	// when the busy timeout is exceeded; the operation that timed out still returns ErrBusyTimeout
+	WatchdogReset bool
+
+	// FlipH and FlipV mirror the image drawn by Draw horizontally and vertically respectively
+	// these are useful when the display is viewed through a mirror or mounted such that rotation alone can't fix it
+	FlipH bool
+	FlipV bool
+
+	// Threshold is the perceived brightness at or below which a pixel is considered dark (and rendered black)
+	// brightness is measured on the same scale as the values returned by color.Color's RGBA method (0 - 65535)
+	Threshold float64
+
+	// Background is the color of the margins around images that don't cover the whole display (such as the ones
+	// drawn using DrawCentered, DrawReader or a Compositor); it defaults to white
+	Background color.Color
+
+	// Pipeline, if set, is applied to every image given to Draw before it's packed
+	// uniform images (such as the ones used by Clear) are not run through the pipeline
+	Pipeline Pipeline
+
+	// Overlay, if set, is called to draw onto every frame just before it's transmitted (after it's packed, or after
+	// a region of the frame buffer is drawn onto), such as to stamp a device id or a timestamp into a corner of every
+	// frame. It's drawn by every method rendering onto the display, so that nothing drawn ever covers it.
+	Overlay func(dst draw.Image)
+
+	// ResetFunc, if set, replaces the default hardware reset sequence (a high-low-high pulse on the reset pin)
+	// for boards that need a different pulse pattern or timing
+	ResetFunc func(rst WriteablePin)
+
+	// ClearOnInit makes Mode fill the device's RAM with white after initialisation (the default)
+	// so that the device never shows whatever noise its RAM held after power-up or a reset
+	ClearOnInit bool
+
+	// SkipIdenticalFrames makes Draw (and its variants) return without touching the device if the frame is identical
+	// to the one in the driver's frame buffer (the default); use ForceDraw to refresh the display regardless
+	SkipIdenticalFrames bool
+
+	// Hybrid, if set, makes the driver in PartialUpdate mode periodically promote a partial refresh
+	// to a full one to clear the ghosting accumulated by partial updates (see HybridRefresh)
+	Hybrid *HybridRefresh
+
+	// PixelMSBFirst maps the left-most pixel of each byte to its most significant bit (the default)
+	// when unset, the left-most pixel is mapped to the least significant bit instead
+	PixelMSBFirst bool
+
+	// BitPolarity selects the value of the bit that denotes a dark pixel in the packed frames sent to the device
+	// it defaults to DarkIsZero, which is what the supported panels expect
+	BitPolarity Polarity
+
+	// PackRow, if set, replaces the default conversion of a row of the image into the device's native format
+	// It must fill dst (which holds Width/8 bytes) with the packed pixels of row y of img.
+	// FlipH and FlipV are not applied when using a custom PackRow.
+	PackRow func(img image.Image, y int, dst []byte)
+
+	// OnBeforeRefresh and OnAfterRefresh, if set, are called right before the display is refreshed and
+	// right after the refresh completes (or fails) respectively; useful for driving external power control
+	OnBeforeRefresh func()
+	OnAfterRefresh  func()
+
+	// GroupWrites makes the driver send each command along with its data in a single transaction (keeping the chip
+	// select asserted throughout) instead of framing every byte on its own. It is off by default as some backends
+	// rely on the per-byte framing, and only takes effect if the Interface implements GroupWriter (SPI does).
+	GroupWrites bool
+
+	// Clock, if set, replaces the real time used by the driver for its delays and timeouts
+	Clock Clock
+
+	// Recorder, if set, records every command and data byte sent to the device
+	Recorder *Recorder
+
 	// pins used by this driver
 	rst  WriteablePin // for reset signal
-	dc   WriteablePin // for data/command select signal; D=HIGH C=LOW
-	cs   WriteablePin // for chip select signal; this pin is active low
 	busy ReadablePin  // for reading in busy signal
 
-	// SPI transmitter
-	transmit Transmit
+	// busyLevel is the level the busy pin is driven to while the device is busy
+	busyLevel uint8
+
+	// lut holds the lookup table uploaded in each mode (only used by V1 panels)
+	lut map[Mode][]byte
+
+	// iface is the transport commands and data are sent over; transceive (optionally) reads over SPI
+	iface      Interface
+	transceive Transceive
+
+	// mode is the last mode the device was initialised into
+	// awake is set when the device is initialised and unset when it's put into deep sleep
+	mode  Mode
+	awake bool
+
+	// powered is set while the analog circuitry is kept powered between refreshes (see PowerOn)
+	powered bool
+
+	// transfer statistics of the RAM writes (see Throughput); chunked is set once the per-transfer overhead
+	// measured over the first full frame is found to be high, making writeRect transmit the rows in a few
+	// large transfers
+	sent, transfers int
+	spent           time.Duration
+	measured        bool
+	chunked         bool
+
+	// customLUT is set while a one-off lookup table is loaded into the device (see DrawWithLUT)
+	customLUT bool
+
+	// recovering is set while the watchdog is resetting the device
+	recovering bool
+
+	// force is set while drawing using ForceDraw, overriding SkipIdenticalFrames
+	force bool
+
+	// partials counts the partial updates since the last full update (or ghost guard cycle)
+	partials int
+
+	// stats counts the refreshes performed over the panel's lifetime (see UpdateStats)
+	// lastFull is the time of the last full update
+	stats    Stats
+	lastFull time.Time
+
+	// progress, if set, is called after each row (or chunk of rows) is transmitted by writeRect (see DrawWithProgress)
+	progress func(done, total int)
+
+	// ctx, if set, is the context of the draw in progress; writeRect stops between rows (or chunks) once it's cancelled
+	// and the refresh is skipped
+	// queue holds the draws queued using DrawAsync, and draining is set while they're being drawn
+	ctx      context.Context
+	mu       sync.Mutex
+	queue    []*Pending
+	draining bool
+
+	// batch holds the regions drawn since Begin was called; it is nil outside of a batch
+	// batchPowered is set if Begin powered the analog circuitry up, for End to power it down
+	batch        []image.Rectangle
+	batchPowered bool
+
+	// target is the RAM bank selected using Target
+	target Bank
+
+	// buffer is the driver's frame buffer holding the last frame written to the device in its native (packed) format
+	// dirty is set when the buffer has been drawn onto but not yet written to the device
+	buffer []byte
+	dirty  bool
 }
 
-// New creates a new EPD device driver
+// New creates a new EPD device driver that talks to the device over 4-wire SPI
 func New(rst, dc, cs WriteablePin, busy ReadablePin, transmit Transmit) *EPD {
-	return &EPD{296, 128, rst, dc, cs, busy, transmit}
+	return NewWithInterface(rst, busy, spi{dc: dc, cs: cs, transmit: transmit})
+}
+
+// NewWithInterface creates a new EPD device driver that sends commands and data to the device using iface
+func NewWithInterface(rst WriteablePin, busy ReadablePin, iface Interface) *EPD {
+	return &EPD{
+		Height: 296, Width: 128,
+		DummyLinePeriod: 0x1A, GateTime: 0x08,
+		PixelMSBFirst: true, Background: color.White, Threshold: 130, ClearOnInit: true, SkipIdenticalFrames: true,
+		Waveforms: map[Mode][]byte{PartialUpdate: partialUpdateV2},
+
+		rst: rst, busy: busy, busyLevel: 0x1,
+		lut:   map[Mode][]byte{FullUpdate: fullUpdate, PartialUpdate: partialUpdate},
+		iface: iface,
+	}
 }
 
 // reset resets the display back to defaults
 func (epd *EPD) reset() {
+	if epd.ResetFunc != nil {
+		epd.ResetFunc(epd.rst)
+		return
+	}
+
 	epd.rst.High()
-	time.Sleep(200 * time.Millisecond)
+	epd.clock().Sleep(200 * time.Millisecond)
 	epd.rst.Low()
-	time.Sleep(10 * time.Millisecond)
+	epd.clock().Sleep(10 * time.Millisecond)
 	epd.rst.High()
-	time.Sleep(200 * time.Millisecond)
+	epd.clock().Sleep(200 * time.Millisecond)
 }
 
-// command transmits single byte of command instruction over the SPI line
+// command transmits single byte of command instruction to the device
 func (epd *EPD) command(c byte) {
-	epd.dc.Low()
-	epd.cs.Low()
-	epd.transmit(c)
-	epd.cs.High()
+	if epd.Recorder != nil {
+		epd.Recorder.command(c)
+	}
+	epd.iface.WriteCommand(c)
 }
 
-// data transmits single byte of data payload over SPI line
+// data transmits single byte of data payload to the device
 func (epd *EPD) data(d byte) {
-	epd.dc.High()
-	epd.cs.Low()
-	epd.transmit(d)
-	epd.cs.High()
+	if epd.Recorder != nil {
+		epd.Recorder.data(d)
+	}
+	epd.iface.WriteData(d)
+}
+
+// stream sends the data payload to the device in a single transmission
+func (epd *EPD) stream(buf []byte) {
+	if epd.Recorder != nil {
+		for _, d := range buf {
+			epd.Recorder.data(d)
+		}
+	}
+	epd.iface.WriteData(buf...)
+}
+
+// send transmits the command along with its data payload
+// With GroupWrites the command and its data are framed by a single transaction, otherwise each byte is on its own.
+func (epd *EPD) send(c byte, data ...byte) {
+	if group, ok := epd.grouped(); ok {
+		epd.record(c, data)
+		group.WriteGroup(c, data...)
+		return
+	}
+
+	epd.command(c)
+	for _, d := range data {
+		epd.data(d)
+	}
+}
+
+// writeRAM transmits the payload into the RAM addressed by the given command, starting at the current cursor
+// Unlike send, the payload is always transmitted in bulk.
+func (epd *EPD) writeRAM(ram byte, buf []byte) {
+	defer epd.measure(len(buf), time.Now())
+	if group, ok := epd.grouped(); ok {
+		epd.record(ram, buf)
+		group.WriteGroup(ram, buf...)
+		return
+	}
+
+	epd.command(ram)
+	epd.stream(buf)
+}
+
+// run sends the steps to the device in order, waiting for it to get into idle state after the steps that require so
+func (epd *EPD) run(steps []Step) error {
+	for _, step := range steps {
+		epd.send(step.Command, step.Data...)
+		if step.Wait {
+			if err := epd.idle(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // idle reads from busy line and waits for the device to get into idle state
-func (epd *EPD) idle() {
-	for epd.busy.Read() == 0x1 {
-		time.Sleep(200 * time.Millisecond)
+func (epd *EPD) idle() error {
+	if epd.Recorder != nil {
+		epd.Recorder.wait()
 	}
+	var start, retries = epd.clock().Now(), 0
+	for epd.isBusy() {
+		if epd.BusyTimeout > 0 && epd.clock().Now().Sub(start) > epd.BusyTimeout {
+			if retries < epd.BusyRetries {
+				epd.clock().Sleep(epd.BusyBackoff << retries)
+				start, retries = epd.clock().Now(), retries+1
+				continue
+			}
+			if epd.WatchdogReset && !epd.recovering {
+				log.Printf("[WARN] epd: device busy for more than %v; resetting", epd.BusyTimeout)
+				epd.recovering = true
+				_ = epd.Mode(epd.mode)
+				epd.recovering = false
+			}
+			return ErrBusyTimeout
+		}
+		epd.waitBusy(epd.pollInterval())
+	}
+	return nil
 }
 
 // mode sets the device's mode (based on the LookupTable)
@@ -116,47 +420,68 @@ func (epd *EPD) idle() {
 // or in PartialUpdate mode where only the changed section is updated (and it doesn't cause any flicker)
 //
 // Waveshare recommends doing full update of the display at least once per-day to prevent ghost image problems
-func (epd *EPD) Mode(mode Mode) {
+func (epd *EPD) Mode(mode Mode) error {
 	epd.reset()
+	return epd.init(mode)
+}
 
-	// command+data below is taken from the python sample driver
-
-	// DRIVER_OUTPUT_CONTROL
-	epd.command(0x01)
-	epd.data(byte((epd.Height - 1) & 0xFF))
-	epd.data(byte(((epd.Height - 1) >> 8) & 0xFF))
-	epd.data(0x00)
-
-	// BOOSTER_SOFT_START_CONTROL
-	epd.command(0x0C)
-	epd.data(0xD7)
-	epd.data(0xD6)
-	epd.data(0x9D)
+// CurrentMode returns the mode the device was last initialised into (using Mode or any of the other init paths)
+// The mode is retained while the device is in deep sleep.
+func (epd *EPD) CurrentMode() Mode {
+	return epd.mode
+}
 
-	// WRITE_VCOM_REGISTER
-	epd.command(0x2C)
-	epd.data(0xA8)
+// init initialises the (freshly reset) device into the given mode
+func (epd *EPD) init(mode Mode) error {
+	epd.mode, epd.awake, epd.powered = mode, true, false
 
-	// SET_DUMMY_LINE_PERIOD
-	epd.command(0x3A)
-	epd.data(0x1A)
+	var steps []Step
+	if epd.Revision == V2 {
+		if err := epd.idle(); err != nil { // the controller must be idle before its software reset
+			return err
+		}
+		steps = epd.initV2(mode)
+	} else {
+		steps = epd.initV1(mode)
+	}
+	if err := epd.run(steps); err != nil || !epd.ClearOnInit {
+		return err
+	}
+	return epd.clearRAM()
+}
 
-	// SET_GATE_TIME
-	epd.command(0x3B)
-	epd.data(0x08)
+// clearRAM fills the device's RAM with white, so that it starts from a known state rather than random noise.
+// The frame buffer is kept, but as it no longer matches the device, it's marked as dirty.
+func (epd *EPD) clearRAM() error {
+	if err := epd.fillRAM(ramBlack, epd.white()); err != nil {
+		return err
+	}
+	if epd.Revision == V2 { // V1 panels don't have the second bank
+		if err := epd.fillRAM(ramRed, epd.white()); err != nil {
+			return err
+		}
+	}
 
-	// DATA_ENTRY_MODE_SETTING
-	epd.command(0x11)
-	epd.data(0x03)
+	epd.dirty = epd.buffer != nil
+	return nil
+}
 
-	// WRITE_LUT_REGISTER
-	epd.command(0x32)
-	var lut = fullUpdate
-	if mode == PartialUpdate {
-		lut = partialUpdate
+// initV1 returns the steps initialising a V1 panel into the given mode
+// command+data below is taken from the python sample driver
+func (epd *EPD) initV1(mode Mode) []Step {
+	var h = epd.Height - 1
+	var booster, gate = []byte{0xD7, 0xD6, 0x9D}, epd.GateTime
+	if p, ok := epd.temperatureProfile(); ok {
+		booster, gate = p.Booster[:], p.GateTime
 	}
-	for _, b := range lut {
-		epd.data(b)
+	return []Step{
+		{Command: 0x01, Data: []byte{byte(h & 0xFF), byte((h >> 8) & 0xFF), epd.GateScan}}, // DRIVER_OUTPUT_CONTROL
+		{Command: 0x0C, Data: booster},                     // BOOSTER_SOFT_START_CONTROL
+		{Command: 0x2C, Data: []byte{0xA8}},                // WRITE_VCOM_REGISTER
+		{Command: 0x3A, Data: []byte{epd.DummyLinePeriod}}, // SET_DUMMY_LINE_PERIOD
+		{Command: 0x3B, Data: []byte{gate}},                // SET_GATE_TIME
+		{Command: 0x11, Data: []byte{0x03}},                // DATA_ENTRY_MODE_SETTING
+		{Command: 0x32, Data: epd.lut[mode]},               // WRITE_LUT_REGISTER
 	}
 }
 
@@ -165,87 +490,355 @@ func (epd *EPD) Mode(mode Mode) {
 // Waveshare recommends putting the device in "deep sleep" mode (or disconnect from power)
 // if doesn't need updating/refreshing.
 func (epd *EPD) Sleep() {
-	epd.command(0x10)
-	epd.data(0x01)
+	epd.send(0x10, 0x01)
+	epd.awake, epd.powered = false, false
 }
 
 // turnOnDisplay activates the display and renders the image that's there in the device's RAM
-func (epd *EPD) turnOnDisplay() {
-	epd.command(0x22)
-	epd.data(0xC4)
+func (epd *EPD) turnOnDisplay() error {
+	if epd.hybridDue() {
+		return epd.loadBase(epd.framebuffer()) // promote the partial update to a full update
+	}
+
+	epd.count()
+	if epd.mode == FullUpdate {
+		epd.partials = 0
+	} else if epd.partials++; epd.GhostGuardEvery > 0 && epd.partials >= epd.GhostGuardEvery {
+		if err := epd.clean(); err != nil {
+			return err
+		}
+	}
+
+	if epd.OnBeforeRefresh != nil {
+		epd.OnBeforeRefresh()
+	}
+
+	epd.send(0x22, epd.sequence())
 	epd.command(0x20)
-	epd.command(0xFF)
-	epd.idle()
+	if epd.Revision != V2 {
+		epd.command(0xFF)
+	}
+	var err = epd.idle()
+	if err == nil && epd.SettleDelay > 0 {
+		epd.clock().Sleep(epd.SettleDelay)
+	}
+
+	if epd.OnAfterRefresh != nil {
+		epd.OnAfterRefresh()
+	}
+	return err
 }
 
 // window sets the window plane used by device when drawing the image in the buffer
 func (epd *EPD) window(x0, x1 byte, y0, y1 uint16) {
-	epd.command(0x44)
-	epd.data((x0 >> 3) & 0xFF)
-	epd.data((x1 >> 3) & 0xFF)
-
-	epd.command(0x45)
-	epd.data(byte(y0 & 0xFF))
-	epd.data(byte((y0 >> 8) & 0xFF))
-	epd.data(byte(y1 & 0xFF))
-	epd.data(byte((y1 >> 8) & 0xFF))
+	epd.send(0x44, (x0>>3)&0xFF, (x1>>3)&0xFF)
+	epd.send(0x45, byte(y0&0xFF), byte((y0>>8)&0xFF), byte(y1&0xFF), byte((y1>>8)&0xFF))
 }
 
 // cursor sets the cursor position in the device window frame
-func (epd *EPD) cursor(x uint8, y uint16) {
-	epd.command(0x4E)
-	epd.data((x >> 3) & 0xFF)
-
-	epd.command(0x4F)
-	epd.data(byte(y & 0xFF))
-	epd.data(byte((y >> 8) & 0xFF))
+func (epd *EPD) cursor(x uint8, y uint16) error {
+	epd.send(0x4E, (x>>3)&0xFF)
+	epd.send(0x4F, byte(y&0xFF), byte((y>>8)&0xFF))
 
-	epd.idle()
+	if !epd.CursorWait {
+		return nil
+	}
+	return epd.idle()
 }
 
 // Clear clears the display and paints the whole display into c color
+// If the driver's frame buffer shows that the display is already painted in that color, the refresh is skipped.
 func (epd *EPD) Clear(c color.Color) {
-	var img = image.White
+	var value = epd.white()
 	if c != color.White {
-		img = image.Black // anything other than white is treated as black
+		value = ^value // anything other than white is treated as black
+	}
+	if epd.Overlay != nil {
+		_ = epd.present(epd.filled(value)) // the frame isn't uniform once the Overlay is drawn onto it
+		return
+	}
+
+	if epd.target != None {
+		_ = epd.FillRAM(epd.target, value)
+		return
+	}
+	if !epd.dirty && epd.buffer != nil && bytes.Count(epd.buffer, []byte{value}) == len(epd.buffer) {
+		return // nothing to clear
+	}
+	if err := epd.FillRAM(Black, value); err == nil {
+		_ = epd.turnOnDisplay()
 	}
-	_ = epd.Draw(img)
 }
 
-// Draw renders the given image onto the display
-func (epd *EPD) Draw(img image.Image) error {
+// Fits reports whether the image can be rendered by Draw, i.e. whether its bounds match the display's dimensions
+// Note that Draw checks the image's bounds after running it through the Pipeline.
+func (epd *EPD) Fits(img image.Image) bool {
 	var isvertical = img.Bounds().Size().X == epd.Width && img.Bounds().Size().Y == epd.Height
 	var _, uniform = img.(*image.Uniform) // special case for uniform images which have infinite bound
-	if !uniform && !isvertical {
+	return uniform || isvertical
+}
+
+// Draw renders the given image onto the display
+func (epd *EPD) Draw(img image.Image) error {
+	return epd.render(epd.preprocess(img))
+}
+
+// preprocess runs the image through the Pipeline
+func (epd *EPD) preprocess(img image.Image) image.Image {
+	if _, uniform := img.(*image.Uniform); uniform {
+		return img
+	}
+	return epd.Pipeline.Apply(img)
+}
+
+// render packs the (already preprocessed) image and presents it
+func (epd *EPD) render(img image.Image) error {
+	if !epd.Fits(img) {
 		return ErrInvalidImageSize
 	}
+	return epd.present(epd.pack(img))
+}
+
+// DrawWithProgress renders the given image onto the display like Draw, calling progress after each row
+// (scanline) of the image is transmitted to the device with the number of rows done out of the total.
+// On transports with a high per-transfer overhead the rows are sent a few at a time (see Throughput),
+// and progress is called after each such chunk.
+func (epd *EPD) DrawWithProgress(img image.Image, progress func(done, total int)) error {
+	epd.progress = progress
+	defer func() { epd.progress = nil }()
+	return epd.Draw(img)
+}
+
+// DrawBuffer renders an already packed frame onto the display
+//
+// The buffer is written to the device as-is: the Pipeline, FlipH / FlipV and the packing options are not applied.
+// Combined with Pack (or Snapshot) this allows static content to be precomputed once and replayed cheaply.
+//
+// The buffer must be in the same layout as the one returned by Snapshot, which by default is also the layout used by
+// Waveshare's reference driver: rows from top to bottom, each row packed 8 pixels per byte with
+// the left-most pixel in the most significant bit (see PixelMSBFirst), and a 0 bit denoting a black pixel (see BitPolarity).
+func (epd *EPD) DrawBuffer(buf []byte) error {
+	if len(buf) != epd.stride()*epd.Height {
+		return ErrInvalidBufferSize
+	}
+
+	return epd.present(append([]byte(nil), buf...))
+}
+
+// present writes the packed frame into the device's RAM and refreshes the display
+// If a bank has been selected using Target, the frame is written into that bank and the display isn't refreshed.
+// The Overlay is drawn onto the frame first, and the frame is skipped if the display already shows it.
+func (epd *EPD) present(buf []byte) error {
+	epd.stamp(buf)
+	if epd.identical(buf) {
+		return nil // nothing has changed
+	}
+	return epd.show(buf)
+}
+
+// show writes the (already stamped) packed frame into the device's RAM and refreshes the display, like present
+func (epd *EPD) show(buf []byte) error {
+	var ram = ramBlack
+	if epd.target != None {
+		ram = epd.target.ram()
+	}
+	if epd.Progressive && epd.target == None {
+		return epd.reveal(buf)
+	}
+
+	if err := epd.write(ram, buf); err != nil {
+		return err
+	}
+	if ram == ramBlack {
+		epd.buffer, epd.dirty = buf, false
+	}
+
+	if epd.target != None {
+		return nil
+	}
+	if err := epd.cancelled(); err != nil {
+		return err // cancelled after the last row was transmitted; skip the refresh
+	}
+	return epd.turnOnDisplay()
+}
+
+// Snapshot returns a copy of the driver's frame buffer in its packed format (see DrawBuffer)
+// This is the last frame rendered onto the display along with anything drawn onto the buffer since.
+// It returns nil if nothing has been drawn yet.
+func (epd *EPD) Snapshot() []byte {
+	if epd.buffer == nil {
+		return nil
+	}
+	return append([]byte(nil), epd.buffer...)
+}
+
+// bounds returns the bounds of the display
+func (epd *EPD) bounds() image.Rectangle {
+	return image.Rect(0, 0, epd.Width, epd.Height)
+}
+
+// background returns the Background, defaulting to white if it's not set
+func (epd *EPD) background() color.Color {
+	if epd.Background == nil {
+		return color.White
+	}
+	return epd.Background
+}
+
+// stride returns the number of bytes used by a single packed row
+func (epd *EPD) stride() int {
+	return (epd.Width + 7) / 8
+}
+
+// Pack runs the image through the Pipeline and converts it into the device's native format, exactly as Draw would,
+// returning the packed frame in the layout described by DrawBuffer. This allows frames to be precomputed offline.
+//
+// For the default 128x296 display, for example:
+//
+//	all white image                    -> 4736 bytes of 0xFF
+//	all black image                    -> 4736 bytes of 0x00
+//	single black pixel at (0, 0)       -> 0x7F followed by 0xFF
+//	single black pixel at (9, 1)       -> byte 17 is 0xBF, rest are 0xFF
+//	diagonal from (0, 0) to (127, 127) -> byte y*16 + y/8 is ^(0x80 >> (y%8)) for y < 128, rest are 0xFF
+//
+// The values above are for the default BitPolarity; with DarkIsOne every byte is inverted.
+// If the display's width isn't a multiple of 8, the unused bits at the end of each row are left white.
+func (epd *EPD) Pack(img image.Image) ([]byte, error) {
+	img = epd.preprocess(img)
+	if !epd.Fits(img) {
+		return nil, ErrInvalidImageSize
+	}
+	return epd.pack(img), nil
+}
 
-	epd.window(0, byte(epd.Width-1), 0, uint16(epd.Height-1))
+// pack converts the image into the device's native format, returning a buffer of stride*Height bytes
+func (epd *EPD) pack(img image.Image) []byte {
+	var packRow = epd.PackRow
+	if packRow == nil {
+		packRow = epd.packRow
+	}
+
+	var stride = epd.stride()
+	var buf = make([]byte, stride*epd.Height)
 	for i := 0; i < epd.Height; i++ {
-		epd.cursor(0, uint16(i))
-		epd.command(0x24) // WRITE_RAM
-		for j := 0; j < epd.Width; j += 8 {
-			// this loop converts individual pixels into a single byte
-			// 8-pixels at a time and then sends that byte to render
-			var b = 0xFF
-			for px := 0; px < 8; px++ {
-				var pixel = img.At(j+px, i)
-				if isdark(pixel.RGBA()) {
-					b &= ^(0x80 >> (px % 8))
-				}
+		packRow(img, i, buf[i*stride:(i+1)*stride])
+	}
+	return buf
+}
+
+// packRow is the default row conversion used by pack
+func (epd *EPD) packRow(img image.Image, y int, dst []byte) {
+	var bounds = img.Bounds()
+	var bgdark, darkAt = epd.dark(epd.background()), epd.darkAt(img)
+
+	for j := 0; j < epd.Width; j += 8 {
+		// this loop converts individual pixels into a single byte
+		// 8-pixels at a time and then stores that byte in the buffer
+		var b = epd.white()
+		for px := 0; px < 8 && j+px < epd.Width; px++ {
+			var x, y = epd.flip(j+px, y)
+			var dark = bgdark
+			if pt := bounds.Min.Add(image.Pt(x, y)); pt.In(bounds) {
+				dark = darkAt(pt.X, pt.Y)
+			}
+			if dark {
+				epd.mark(&b, px, true)
 			}
-			epd.data(byte(b))
+		}
+		dst[j/8] = b
+	}
+}
+
+// bit returns the mask of the bit holding the pixel at x within its byte, based on PixelMSBFirst
+func (epd *EPD) bit(x int) byte {
+	if epd.PixelMSBFirst {
+		return 0x80 >> (x % 8)
+	}
+	return 0x01 << (x % 8)
+}
+
+// flip maps the device's (x, y) coordinates to the source image's coordinates based on FlipH and FlipV
+func (epd *EPD) flip(x, y int) (int, int) {
+	if epd.FlipH {
+		x = epd.Width - 1 - x
+	}
+	if epd.FlipV {
+		y = epd.Height - 1 - y
+	}
+	return x, y
+}
+
+// write transmits the packed buffer into the given RAM bank of the device
+func (epd *EPD) write(ram byte, buf []byte) error {
+	return epd.writeRect(ram, buf, epd.bounds())
+}
+
+// writeRect transmits the part of the packed buffer covered by r into the given RAM bank of the device
+// As each byte holds 8 horizontal pixels, r is widened to byte boundaries on the x-axis.
+func (epd *EPD) writeRect(ram byte, buf []byte, r image.Rectangle) error {
+	var stride = epd.stride()
+	var x0, x1 = r.Min.X &^ 7, (r.Max.X + 7) &^ 7
+	epd.window(byte(x0), byte(x1-1), uint16(r.Min.Y), uint16(r.Max.Y-1))
+	if epd.chunked {
+		return epd.writeChunk(ram, buf, image.Rect(x0, r.Min.Y, x1, r.Max.Y))
+	}
+
+	defer epd.calibrate(r, epd.spent, epd.transfers)
+	for i := r.Min.Y; i < r.Max.Y; i++ {
+		if err := epd.cancelled(); err != nil {
+			return err
+		}
+		if err := epd.cursor(byte(x0), uint16(i)); err != nil {
+			return err
+		}
+		epd.writeRAM(ram, buf[i*stride+x0/8:i*stride+x1/8])
+		if epd.progress != nil {
+			epd.progress(i-r.Min.Y+1, r.Dy())
 		}
 	}
-	epd.turnOnDisplay()
 	return nil
 }
 
-// isdark is a utility method which returns true if the pixel color is considered dark else false
+// dark returns true if the pixel color is considered dark (based on the Threshold) else false
+func (epd *EPD) dark(c color.Color) bool {
+	var r, g, b, _ = c.RGBA()
+	return epd.darkRGB(r, g, b)
+}
+
+// darkAt returns a function reporting whether the pixel of img at (x, y) is dark
+// For the most common in-memory image types, the pixels are read straight from their backing slice
+// to avoid going through At (and the conversions of color.Color) for every pixel.
+func (epd *EPD) darkAt(img image.Image) func(x, y int) bool {
+	switch img := img.(type) {
+	case *image.RGBA:
+		return func(x, y int) bool {
+			var p = img.Pix[img.PixOffset(x, y):]
+			return epd.darkRGB(uint32(p[0])*0x101, uint32(p[1])*0x101, uint32(p[2])*0x101)
+		}
+	case *image.NRGBA:
+		return func(x, y int) bool {
+			var p = img.Pix[img.PixOffset(x, y):]
+			var a = uint32(p[3])
+			return epd.darkRGB(uint32(p[0])*0x101*a/0xFF, uint32(p[1])*0x101*a/0xFF, uint32(p[2])*0x101*a/0xFF)
+		}
+	}
+	return func(x, y int) bool { return epd.dark(img.At(x, y)) }
+}
+
+// darkRGB returns true if the color with the given (alpha-premultiplied, 16-bit) components is considered dark
+func (epd *EPD) darkRGB(r, g, b uint32) bool {
+	// compare the squares to skip the square root on this hot path; both the sides are non-negative
+	return epd.Threshold >= 0 && brightness2(r, g, b) <= epd.Threshold*epd.Threshold
+}
+
+// brightness is a utility method which returns the perceived brightness of the color
 // this function is taken from https://git.io/JviWg
-func isdark(r, g, b, _ uint32) bool {
-	return math.Sqrt(
-		0.299*math.Pow(float64(r), 2)+
-			0.587*math.Pow(float64(g), 2)+
-			0.114*math.Pow(float64(b), 2)) <= 130
+func brightness(r, g, b uint32) float64 {
+	return math.Sqrt(brightness2(r, g, b))
+}
+
+// brightness2 returns the square of the perceived brightness of the color
+func brightness2(r, g, b uint32) float64 {
+	var fr, fg, fb = float64(r), float64(g), float64(b)
+	return 0.299*fr*fr + 0.587*fg*fg + 0.114*fb*fb
 }
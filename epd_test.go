@@ -0,0 +1,231 @@
+package epd
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+	"time"
+)
+
+// nop is a no-op Interface and pin, with the busy line never signalling that the device is busy
+type nop struct{}
+
+func (nop) WriteCommand(byte) {}
+func (nop) WriteData(...byte) {}
+func (nop) High()             {}
+func (nop) Low()              {}
+func (nop) Read() uint8       { return 0 }
+
+// fakeClock is a Clock whose time only advances when it's slept on
+type fakeClock struct {
+	now    time.Time
+	slept  time.Duration   // total duration slept
+	sleeps []time.Duration // each of the durations slept, in order
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.now = c.now.Add(d)
+	c.slept += d
+	c.sleeps = append(c.sleeps, d)
+}
+
+// newTestEPD returns a driver for a display of the given size talking to a no-op device using a fake clock,
+// along with a Recorder recording the command stream sent to it
+func newTestEPD(width, height int) (*EPD, *Recorder) {
+	var epd = NewWithInterface(nop{}, nop{}, nop{})
+	epd.Width, epd.Height = width, height
+	epd.Clock = &fakeClock{now: time.Unix(0, 0)}
+	epd.Recorder = &Recorder{}
+	return epd, epd.Recorder
+}
+
+// written returns the data of all the recorded steps sending the command c, in order
+func written(rec *Recorder, c byte) []byte {
+	var buf []byte
+	for _, step := range rec.Steps() {
+		if step.Command == c {
+			buf = append(buf, step.Data...)
+		}
+	}
+	return buf
+}
+
+// gray returns a white image of the given size with the given pixels set to black
+func gray(width, height int, black ...image.Point) *image.Gray {
+	var img = image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	for _, pt := range black {
+		img.SetGray(pt.X, pt.Y, color.Gray{})
+	}
+	return img
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	var epd, rec = newTestEPD(16, 3)
+
+	// row 0: left half black; row 1: single black pixel at (9, 1); row 2: every other pixel black
+	var black = []image.Point{{X: 9, Y: 1}}
+	for x := 0; x < 16; x++ {
+		if x < 8 {
+			black = append(black, image.Pt(x, 0))
+		}
+		if x%2 == 0 {
+			black = append(black, image.Pt(x, 2))
+		}
+	}
+
+	// reference layout, as used by Waveshare's reference driver
+	var reference = []byte{
+		0x00, 0xFF,
+		0xFF, 0xBF,
+		0x55, 0x55,
+	}
+
+	var buf, err = epd.Pack(gray(16, 3, black...))
+	if err != nil {
+		t.Fatalf("Pack() failed: %v", err)
+	}
+	if !bytes.Equal(buf, reference) {
+		t.Fatalf("Pack() = % X, want % X", buf, reference)
+	}
+
+	if err := epd.DrawBuffer(buf); err != nil {
+		t.Fatalf("DrawBuffer() failed: %v", err)
+	}
+	if ram := written(rec, ramBlack); !bytes.Equal(ram, reference) {
+		t.Errorf("DrawBuffer() wrote % X into RAM, want % X", ram, reference)
+	}
+	if snapshot := epd.Snapshot(); !bytes.Equal(snapshot, reference) {
+		t.Errorf("Snapshot() = % X, want % X", snapshot, reference)
+	}
+}
+
+// packed returns a packed frame of n bytes set to b, with the given bytes then overridden
+func packed(n int, b byte, set map[int]byte) []byte {
+	var buf = bytes.Repeat([]byte{b}, n)
+	for i, v := range set {
+		buf[i] = v
+	}
+	return buf
+}
+
+// black returns a black image of the given size
+func black(width, height int) *image.Gray {
+	return image.NewGray(image.Rect(0, 0, width, height))
+}
+
+func TestPack(t *testing.T) {
+	var diagonal = make(map[int]byte)
+	var line []image.Point
+	for y := 0; y < 128; y++ {
+		diagonal[y*16+y/8] = ^byte(0x80 >> (y % 8))
+		line = append(line, image.Pt(y, y))
+	}
+
+	// on the 122 pixels wide panel the last byte of each row holds 2 pixels, followed by 6 bits of padding
+	var lastBlack = make(map[int]byte)
+	for y := 0; y < 250; y++ {
+		lastBlack[y*16+15] = 0x3F
+	}
+
+	var tests = []struct {
+		name          string
+		width, height int
+		img           image.Image
+		want          []byte
+	}{
+		{"all white", 128, 296, gray(128, 296), packed(4736, 0xFF, nil)},
+		{"all black", 128, 296, black(128, 296), packed(4736, 0x00, nil)},
+		{"pixel at (0, 0)", 128, 296, gray(128, 296, image.Pt(0, 0)), packed(4736, 0xFF, map[int]byte{0: 0x7F})},
+		{"pixel at (9, 1)", 128, 296, gray(128, 296, image.Pt(9, 1)), packed(4736, 0xFF, map[int]byte{17: 0xBF})},
+		{"diagonal", 128, 296, gray(128, 296, line...), packed(4736, 0xFF, diagonal)},
+		{"all black with padding", 122, 250, black(122, 250), packed(4000, 0x00, lastBlack)},
+		{"last pixel of a row", 122, 250, gray(122, 250, image.Pt(121, 0)), packed(4000, 0xFF, map[int]byte{15: 0xBF})},
+	}
+	for _, tt := range tests {
+		var epd, _ = newTestEPD(tt.width, tt.height)
+		var buf, err = epd.Pack(tt.img)
+		if err != nil {
+			t.Errorf("Pack(%s) failed: %v", tt.name, err)
+			continue
+		}
+		if !bytes.Equal(buf, tt.want) {
+			t.Errorf("Pack(%s) = % X, want % X", tt.name, buf, tt.want)
+		}
+	}
+}
+
+// halves returns an image of the given size with its left half black and its right half white
+func halves(width, height int) image.Image {
+	var black []image.Point
+	for y := 0; y < height; y++ {
+		for x := 0; x < width/2; x++ {
+			black = append(black, image.Pt(x, y))
+		}
+	}
+	return gray(width, height, black...)
+}
+
+func TestBitPolarity(t *testing.T) {
+	var tests = []struct {
+		polarity      Polarity
+		width, height int
+		want          []byte
+	}{
+		{DarkIsZero, 16, 2, []byte{0x00, 0xFF, 0x00, 0xFF}},
+		{DarkIsOne, 16, 2, []byte{0xFF, 0x00, 0xFF, 0x00}},
+
+		// padding bits at the end of each row are always light
+		{DarkIsZero, 12, 1, []byte{0x03, 0xFF}},
+		{DarkIsOne, 12, 1, []byte{0xFC, 0x00}},
+	}
+	for _, tt := range tests {
+		var epd, _ = newTestEPD(tt.width, tt.height)
+		epd.BitPolarity = tt.polarity
+
+		var buf, err = epd.Pack(halves(tt.width, tt.height))
+		if err != nil {
+			t.Fatalf("Pack() failed: %v", err)
+		}
+		if !bytes.Equal(buf, tt.want) {
+			t.Errorf("Pack() of a %dx%d half-black image with polarity %d = % X, want % X",
+				tt.width, tt.height, tt.polarity, buf, tt.want)
+		}
+	}
+}
+
+// benchImage returns a representative full-frame image for the default display: a grayscale gradient
+func benchImage() image.Image {
+	var img = image.NewRGBA(image.Rect(0, 0, 128, 296))
+	for y := 0; y < 296; y++ {
+		for x := 0; x < 128; x++ {
+			var v = uint8((x*2 + y) % 256)
+			img.SetRGBA(x, y, color.RGBA{R: v, G: v, B: v, A: 0xFF})
+		}
+	}
+	return img
+}
+
+func BenchmarkPack(b *testing.B) {
+	var epd, img = NewWithInterface(nop{}, nop{}, nop{}), benchImage()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		epd.pack(img)
+	}
+}
+
+func BenchmarkDraw(b *testing.B) {
+	var epd, img = NewWithInterface(nop{}, nop{}, nop{}), benchImage()
+	epd.SkipIdenticalFrames = false // transmit every frame
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := epd.Draw(img); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Package epdrpio sets up the epd driver on a Raspberry Pi using go-rpio
+package epdrpio // import "go.riyazali.net/epd/epdrpio"
+
+import (
+	"github.com/stianeikeland/go-rpio/v4"
+
+	"go.riyazali.net/epd"
+)
+
+// Pins are the (BCM numbered) GPIO pins the display is connected to
+type Pins struct {
+	Reset, DC, CS, Busy rpio.Pin
+
+	// Read enables reading from the device (see epd.EPD.SetTransceive), eg. for epd.EPD.ReadTemperature
+	// The panel's SPI interface is 3-wire, with DIN doubling as its data output, so reading needs DIN to be
+	// connected to MISO (GPIO 9) as well as to MOSI (GPIO 10) through a resistor (of around 1kΩ) that protects
+	// the pins while the panel drives the line. Waveshare's HAT and modules don't wire up MISO, so reading
+	// is disabled for HAT; without the wiring, reads return whatever the floating line happens to hold.
+	Read bool
+}
+
+// HAT are the pins used by Waveshare's e-paper HAT (and its reference drivers)
+var HAT = Pins{Reset: 17, DC: 25, CS: 8, Busy: 24}
+
+// Speed is the clock speed of the SPI bus set up by Open
+const Speed = 4_000_000
+
+// busyPin adapts rpio.Pin to epd.ReadablePin
+type busyPin struct{ rpio.Pin }
+
+func (pin busyPin) Read() uint8 { return uint8(pin.Pin.Read()) }
+
+// Open sets up the GPIO and the SPI0 bus (see epd.SPIMode) and creates a new driver for the display connected
+// to the given pins, also able to read from the device if the pins are wired up for it (see Pins.Read).
+// The returned function releases the bus and the GPIO, and should be called once done.
+func Open(pins Pins) (*epd.EPD, func() error, error) {
+	if err := rpio.Open(); err != nil {
+		return nil, nil, err
+	}
+	if err := rpio.SpiBegin(rpio.Spi0); err != nil {
+		_ = rpio.Close()
+		return nil, nil, err
+	}
+
+	// go-rpio always transfers 8-bit words, matching epd.SPIBitsPerWord
+	rpio.SpiSpeed(Speed)
+	rpio.SpiMode(epd.SPIMode>>1, epd.SPIMode&1)
+
+	for _, pin := range []rpio.Pin{pins.Reset, pins.DC, pins.CS} {
+		pin.Output()
+	}
+	pins.Busy.Input()
+
+	var display = epd.New(pins.Reset, pins.DC, pins.CS, busyPin{pins.Busy}, rpio.SpiTransmit)
+	display.SPIHz = Speed
+	if pins.Read {
+		display.SetTransceive(epd.Exchange(rpio.SpiExchange))
+	}
+
+	var release = func() error {
+		rpio.SpiEnd(rpio.Spi0)
+		return rpio.Close()
+	}
+	return display, release, nil
+}
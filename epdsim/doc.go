@@ -0,0 +1,5 @@
+// Package epdsim provides a simulated device for developing with the epd driver without any hardware
+//
+// The simulator decodes the command stream sent by the driver exactly as the panel's controller would,
+// maintaining the controller's RAM and rendering it onto a simulated display on every refresh.
+package epdsim // import "go.riyazali.net/epd/epdsim"
@@ -0,0 +1,142 @@
+package epdsim
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+
+	"go.riyazali.net/epd"
+)
+
+// Simulator is a simulated panel, implementing the transport (see epd.Interface) and the pins used by the driver
+//
+// It assumes the driver's default packing (see epd.EPD.PixelMSBFirst and epd.EPD.BitPolarity), rendering
+// a 0 bit as a black pixel.
+type Simulator struct {
+	// OnRefresh, if set, is called with the simulated display after every refresh
+	// This can be used to save every frame to a file or to show it in a window.
+	OnRefresh func(img image.Image)
+
+	width, height int
+	stride        int
+
+	ram     map[byte][]byte // contents of the RAM banks, keyed by the command writing into them
+	display *image.Gray     // what the simulated display currently shows
+
+	cmd  byte   // last command received
+	args []byte // data received since the last command
+	seq  byte   // display update sequence (see DISPLAY_UPDATE_CONTROL_2)
+
+	xs, xe, ys, ye int // RAM window; x in bytes and y in rows
+	x, y           int // RAM address counter
+}
+
+// New creates a new simulated panel with the given dimensions (in pixels)
+func New(width, height int) *Simulator {
+	var stride = (width + 7) / 8
+	var display = image.NewGray(image.Rect(0, 0, width, height))
+	for i := range display.Pix {
+		display.Pix[i] = 0xFF
+	}
+
+	return &Simulator{
+		width: width, height: height, stride: stride,
+		ram:     map[byte][]byte{0x24: make([]byte, stride*height), 0x26: make([]byte, stride*height)},
+		display: display,
+		xe:      stride - 1, ye: height - 1,
+	}
+}
+
+// Driver creates a new driver, configured with the panel's dimensions, talking to the simulated panel
+func (sim *Simulator) Driver() *epd.EPD {
+	var driver = epd.NewWithInterface(pin{}, pin{}, sim)
+	driver.Width, driver.Height = sim.width, sim.height
+	return driver
+}
+
+// Image returns what the simulated display currently shows
+func (sim *Simulator) Image() image.Image {
+	return sim.display
+}
+
+// WritePNG encodes what the simulated display currently shows as a PNG image and writes it to w
+func (sim *Simulator) WritePNG(w io.Writer) error {
+	return png.Encode(w, sim.display)
+}
+
+func (sim *Simulator) WriteCommand(c byte) {
+	sim.cmd, sim.args = c, sim.args[:0]
+
+	switch c {
+	case 0x12: // SW_RESET
+		sim.xs, sim.xe, sim.ys, sim.ye = 0, sim.stride-1, 0, sim.height-1
+		sim.x, sim.y = 0, 0
+	case 0x20: // MASTER_ACTIVATION
+		if sim.seq&0x04 != 0 { // only if the sequence displays the pattern (and not when it just powers on / off)
+			sim.refresh()
+		}
+	}
+}
+
+func (sim *Simulator) WriteData(data ...byte) {
+	if ram, ok := sim.ram[sim.cmd]; ok {
+		for _, b := range data {
+			sim.write(ram, b)
+		}
+		return
+	}
+
+	sim.args = append(sim.args, data...)
+	var a = sim.args
+	switch {
+	case sim.cmd == 0x22 && len(a) == 1: // DISPLAY_UPDATE_CONTROL_2
+		sim.seq = a[0]
+	case sim.cmd == 0x44 && len(a) == 2: // SET_RAM_X_ADDRESS_START_END_POSITION
+		sim.xs, sim.xe = int(a[0]), int(a[1])
+	case sim.cmd == 0x45 && len(a) == 4: // SET_RAM_Y_ADDRESS_START_END_POSITION
+		sim.ys, sim.ye = int(a[0])|int(a[1])<<8, int(a[2])|int(a[3])<<8
+	case sim.cmd == 0x4E && len(a) == 1: // SET_RAM_X_ADDRESS_COUNTER
+		sim.x = int(a[0])
+	case sim.cmd == 0x4F && len(a) == 2: // SET_RAM_Y_ADDRESS_COUNTER
+		sim.y = int(a[0]) | int(a[1])<<8
+	}
+}
+
+// write stores the byte at the address counter and advances it, wrapping around within the window
+// (as per the data entry mode used by the driver: x increments first, then y)
+func (sim *Simulator) write(ram []byte, b byte) {
+	if sim.x < sim.stride && sim.y < sim.height {
+		ram[sim.y*sim.stride+sim.x] = b
+	}
+	if sim.x++; sim.x > sim.xe {
+		if sim.x, sim.y = sim.xs, sim.y+1; sim.y > sim.ye {
+			sim.y = sim.ys
+		}
+	}
+}
+
+// refresh renders the contents of the RAM onto the simulated display
+func (sim *Simulator) refresh() {
+	var ram = sim.ram[0x24]
+	for y := 0; y < sim.height; y++ {
+		for x := 0; x < sim.width; x++ {
+			var c = color.Gray{Y: 0xFF}
+			if ram[y*sim.stride+x/8]&(0x80>>(x%8)) == 0 {
+				c = color.Gray{}
+			}
+			sim.display.SetGray(x, y, c)
+		}
+	}
+
+	if sim.OnRefresh != nil {
+		sim.OnRefresh(sim.display)
+	}
+}
+
+// pin is a simulated pin; the reset pin ignores its level and the busy pin never reports the device as busy
+type pin struct{}
+
+func (pin) High()       {}
+func (pin) Low()        {}
+func (pin) Read() uint8 { return 0 }
@@ -0,0 +1,4 @@
+// Package epdtinygo adapts TinyGo's machine package to the epd driver
+//
+// The adapter is only built when compiling with TinyGo.
+package epdtinygo // import "go.riyazali.net/epd/epdtinygo"
@@ -0,0 +1,42 @@
+//go:build tinygo
+// +build tinygo
+
+package epdtinygo
+
+import (
+	"machine"
+
+	"go.riyazali.net/epd"
+)
+
+// SPI is the subset of machine.SPI used by the driver
+type SPI interface {
+	Tx(w, r []byte) error
+}
+
+// busyPin adapts machine.Pin to epd.ReadablePin
+type busyPin struct{ machine.Pin }
+
+func (pin busyPin) Read() uint8 {
+	if pin.Get() {
+		return 1
+	}
+	return 0
+}
+
+// Config returns the configuration of the SPI bus expected by the driver (see epd.SPIMode) at the given frequency
+// TinyGo always transfers 8-bit words, matching epd.SPIBitsPerWord.
+func Config(hz uint32) machine.SPIConfig {
+	return machine.SPIConfig{Frequency: hz, Mode: epd.SPIMode, LSBFirst: !epd.SPIMSBFirst}
+}
+
+// New configures the given pins and creates a new driver that transmits over the spi bus
+// The bus must already be configured (see Config).
+func New(rst, dc, cs, busy machine.Pin, spi SPI) *epd.EPD {
+	for _, pin := range []machine.Pin{rst, dc, cs} {
+		pin.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	}
+	busy.Configure(machine.PinConfig{Mode: machine.PinInput})
+
+	return epd.New(rst, dc, cs, busyPin{busy}, func(data ...byte) { _ = spi.Tx(data, nil) })
+}
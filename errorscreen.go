@@ -0,0 +1,51 @@
+package epd
+
+import (
+	"fmt"
+	"image"
+
+	"golang.org/x/image/font/basicfont"
+)
+
+// DrawError renders a framed error screen showing msg, centered on the display
+//
+// It is meant as a last resort for unattended devices and so can be called regardless of the state of the device
+// (even from a panic recovery path): the device is reset and re-initialised in FullUpdate mode,
+// and any panic raised whilst drawing is returned as an error.
+func (epd *EPD) DrawError(msg string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("epd: failed to draw error screen: %v", r)
+		}
+	}()
+
+	if err = epd.Mode(FullUpdate); err != nil {
+		return err
+	}
+
+	const border, padding = 2, 6
+	var face = basicfont.Face7x13
+	var lh = MeasureText("", face).Y
+
+	var inner = epd.bounds().Inset(border + padding)
+	var lines = wrap(msg, face, inner.Dx())
+	if n := inner.Dy()/lh - 2; len(lines) > n && n >= 0 {
+		lines = lines[:n] // leave room for the title and the blank line below it
+	}
+
+	var h = (len(lines)+2)*lh + 2*(border+padding)
+	var box = image.Rect(0, (epd.Height-h)/2, epd.Width, (epd.Height+h)/2)
+
+	epd.fill(epd.bounds(), false)
+	epd.stroke(box, border)
+
+	var y = box.Min.Y + border + padding
+	epd.DrawTextAligned("ERROR", face, image.Rect(inner.Min.X, y, inner.Max.X, y+lh), AlignCenter)
+	y += 2 * lh // leave a blank line below the title
+	for _, line := range lines {
+		epd.DrawTextAligned(line, face, image.Rect(inner.Min.X, y, inner.Max.X, y+lh), AlignCenter)
+		y += lh
+	}
+
+	return epd.Commit()
+}
@@ -2,38 +2,20 @@ package main
 
 import (
 	"github.com/fogleman/gg"
-	"github.com/stianeikeland/go-rpio/v4"
 	"go.riyazali.net/epd"
+	"go.riyazali.net/epd/epdrpio"
 	"image/color"
 	"log"
 )
 
-func init() {
-	//start the GPIO controller
-	if err := rpio.Open(); err != nil {
-		log.Fatalf("[FATAL] failed to start gpio: %v", err)
-	}
-
-	// Enable SPI on SPI0
-	if err := rpio.SpiBegin(rpio.Spi0); err != nil {
-		log.Fatalf("[FATAL] failed to enable SPI: %v", err)
-	}
-
-	// configure SPI settings
-	rpio.SpiSpeed(4_000_000)
-	rpio.SpiMode(0, 0)
-
-	rpio.Pin(17).Mode(rpio.Output)
-	rpio.Pin(25).Mode(rpio.Output)
-	rpio.Pin(8).Mode(rpio.Output)
-	rpio.Pin(24).Mode(rpio.Input)
-}
-
 func main() {
-	defer rpio.Close()
+	// set up the GPIO and SPI bus, and initialize the driver
+	var display, closer, err = epdrpio.Open(epdrpio.HAT)
+	if err != nil {
+		log.Fatalf("[FATAL] failed to set up the display: %v", err)
+	}
+	defer closer()
 
-	// initialize the driver
-	var display = epd.New(rpio.Pin(17), rpio.Pin(25), rpio.Pin(8), ReadablePinPatch{rpio.Pin(24)}, rpio.SpiTransmit)
 	display.Mode(epd.PartialUpdate)
 
 	// create an image canvas and draw on it
@@ -67,7 +49,3 @@ func main() {
 
 	display.Sleep()
 }
-
-type ReadablePinPatch struct { rpio.Pin }
-
-func (pin ReadablePinPatch) Read() uint8 { return uint8(pin.Pin.Read()) }
@@ -0,0 +1,53 @@
+package epd
+
+import (
+	"image"
+	"image/color"
+)
+
+// Fade transitions the display from one frame to another, approximating a crossfade on the 1-bit panel by
+// rendering steps-1 dithered blends of the two frames before rendering the final frame. Each step is a separate
+// refresh in the current mode, so 3 - 4 steps are usually enough. With steps less than 2, to is simply drawn.
+//
+// Both the frames are run through the configured Pipeline and must be of the same size.
+func (epd *EPD) Fade(from, to image.Image, steps int) error {
+	from, to = epd.preprocess(from), epd.preprocess(to)
+	if !epd.Fits(from) || !epd.Fits(to) {
+		return ErrInvalidImageSize
+	}
+
+	var b = epd.extent(to)
+	if _, uniform := from.(*image.Uniform); !uniform && from.Bounds().Size() != b.Size() {
+		return ErrInvalidImageSize
+	}
+
+	var dither = Dither()
+	for i := 1; i < steps; i++ {
+		if err := epd.render(dither(blend(from, to, b, float64(i)/float64(steps)))); err != nil {
+			return err
+		}
+	}
+	return epd.render(to)
+}
+
+// extent returns the bounds of the image, or the bounds of the display for uniform images (which are infinite)
+func (epd *EPD) extent(img image.Image) image.Rectangle {
+	if _, uniform := img.(*image.Uniform); uniform {
+		return epd.bounds()
+	}
+	return img.Bounds()
+}
+
+// blend returns a grayscale image mixing the luminance of the two images over the region r (in to's coordinates),
+// with t (between 0 and 1) being the weight of to
+func blend(from, to image.Image, r image.Rectangle, t float64) *image.Gray {
+	var off = from.Bounds().Min.Sub(r.Min)
+	var out = image.NewGray(r)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			var a, b = float64(luminance(from.At(x+off.X, y+off.Y))), float64(luminance(to.At(x, y)))
+			out.SetGray(x, y, color.Gray{Y: uint8(a + (b-a)*t + 0.5)})
+		}
+	}
+	return out
+}
@@ -0,0 +1,25 @@
+package epd
+
+import "image"
+
+// DrawMono1bpp renders an image that has already been reduced to black and white onto the display
+//
+// It's a fast path for the output of dithering or thresholding steps: any non-zero pixel is treated as white
+// and zero as black, skipping the luminance computation done by Draw. The Pipeline and PackRow are not applied.
+func (epd *EPD) DrawMono1bpp(img *image.Gray) error {
+	if !epd.Fits(img) {
+		return ErrInvalidImageSize
+	}
+
+	var stride, min = epd.stride(), img.Bounds().Min
+	var buf = epd.filled(epd.white())
+	for y := 0; y < epd.Height; y++ {
+		for x := 0; x < epd.Width; x++ {
+			var sx, sy = epd.flip(x, y)
+			if img.Pix[img.PixOffset(min.X+sx, min.Y+sy)] == 0 {
+				epd.mark(&buf[y*stride+x/8], x, true)
+			}
+		}
+	}
+	return epd.present(buf)
+}
@@ -0,0 +1,30 @@
+package epd
+
+import "bytes"
+
+// FillRAM fills the given RAM bank of the device with the repeated value, without refreshing the display
+//
+// Unlike drawing a uniform image, the whole bank is written in a single transmission, making it a cheap way
+// to clear the device or to prepare the banks for partial updates. Filling the Black bank also fills
+// the driver's frame buffer with the value.
+func (epd *EPD) FillRAM(bank Bank, value byte) error {
+	var ram = bank.ram()
+	if err := epd.fillRAM(ram, value); err != nil {
+		return err
+	}
+	if ram == ramBlack {
+		epd.buffer, epd.dirty = epd.filled(value), false
+	}
+	return nil
+}
+
+// fillRAM writes the repeated value over the whole of the RAM addressed by the given command
+// It relies on the device advancing (and wrapping) the address counter within the window after each byte.
+func (epd *EPD) fillRAM(ram byte, value byte) error {
+	epd.window(0, byte(epd.stride()*8-1), 0, uint16(epd.Height-1))
+	if err := epd.cursor(0, 0); err != nil {
+		return err
+	}
+	epd.writeRAM(ram, bytes.Repeat([]byte{value}, epd.stride()*epd.Height))
+	return nil
+}
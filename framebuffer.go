@@ -0,0 +1,105 @@
+package epd
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Commit writes any pending changes in the frame buffer to the device and refreshes the display
+func (epd *EPD) Commit() error {
+	if !epd.stamp(epd.framebuffer()).Empty() {
+		epd.dirty = true
+	}
+	if epd.dirty {
+		if err := epd.flush(); err != nil {
+			return err
+		}
+	}
+	return epd.turnOnDisplay()
+}
+
+// flush writes the whole frame buffer into the device's RAM without refreshing the display
+func (epd *EPD) flush() error {
+	if err := epd.write(ramBlack, epd.framebuffer()); err != nil {
+		return err
+	}
+	epd.dirty = false
+	return nil
+}
+
+// Image returns a draw.Image backed by the driver's frame buffer
+//
+// It can be used with image/draw (and anything built on it, such as font drawers) to compose content
+// directly onto the frame buffer. Pixels are stored as black or white based on the Threshold.
+// Anything drawn onto it is only rendered onto the display once Commit is called.
+func (epd *EPD) Image() draw.Image {
+	return frame{epd}
+}
+
+// framebuffer returns the driver's frame buffer, allocating a blank (white) one if nothing has been drawn yet
+func (epd *EPD) framebuffer() []byte {
+	if epd.buffer == nil {
+		epd.buffer = epd.filled(epd.white())
+	}
+	return epd.buffer
+}
+
+// filled returns a new packed frame with every byte set to b
+func (epd *EPD) filled(b byte) []byte {
+	var buf = make([]byte, epd.stride()*epd.Height)
+	for i := range buf {
+		buf[i] = b
+	}
+	return buf
+}
+
+// pixel reports whether the pixel at (x, y) in the frame buffer is dark
+func (epd *EPD) pixel(x, y int) bool {
+	return epd.marked(epd.framebuffer()[y*epd.stride()+x/8], x)
+}
+
+// setpixel sets the pixel at (x, y) in the frame buffer to either dark or light
+func (epd *EPD) setpixel(x, y int, dark bool) {
+	epd.mark(&epd.framebuffer()[y*epd.stride()+x/8], x, dark)
+}
+
+// frame is a draw.Image backed by the driver's frame buffer
+// Anything drawn onto it is only rendered onto the display once Commit is called.
+type frame struct{ epd *EPD }
+
+func (f frame) ColorModel() color.Model { return color.GrayModel }
+
+func (f frame) Bounds() image.Rectangle { return f.epd.bounds() }
+
+func (f frame) At(x, y int) color.Color {
+	if !(image.Point{X: x, Y: y}.In(f.Bounds())) {
+		return color.White
+	}
+	if f.epd.pixel(x, y) {
+		return color.Black
+	}
+	return color.White
+}
+
+func (f frame) Set(x, y int, c color.Color) {
+	if !(image.Point{X: x, Y: y}.In(f.Bounds())) {
+		return
+	}
+	f.epd.setpixel(x, y, f.epd.dark(c))
+	f.epd.dirty = true
+}
+
+// clipped restricts drawing onto the underlying image to the region r
+type clipped struct {
+	draw.Image
+	r image.Rectangle
+}
+
+func (c clipped) Bounds() image.Rectangle { return c.r.Intersect(c.Image.Bounds()) }
+
+func (c clipped) Set(x, y int, col color.Color) {
+	if (image.Point{X: x, Y: y}.In(c.Bounds())) {
+		c.Image.Set(x, y, col)
+	}
+}
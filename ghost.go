@@ -0,0 +1,34 @@
+package epd
+
+// DrawClean removes any ghosting by refreshing the whole display to black and then to white using full updates,
+// after which the driver's frame buffer is redrawn. The device is left in the mode it was in.
+func (epd *EPD) DrawClean() error {
+	if err := epd.clean(); err != nil {
+		return err
+	}
+	return epd.turnOnDisplay()
+}
+
+// clean runs the black and white refresh cycle of DrawClean, re-initialises the device into its previous mode
+// and writes the frame buffer back into the device's RAM without refreshing the display
+func (epd *EPD) clean() error {
+	var mode = epd.mode
+	if err := epd.Mode(FullUpdate); err != nil {
+		return err
+	}
+
+	for _, b := range []byte{^epd.white(), epd.white()} {
+		if err := epd.write(ramBlack, epd.filled(b)); err != nil {
+			return err
+		}
+		if err := epd.turnOnDisplay(); err != nil {
+			return err
+		}
+	}
+
+	epd.partials = 0
+	if err := epd.Mode(mode); err != nil {
+		return err
+	}
+	return epd.flush()
+}
@@ -0,0 +1,34 @@
+package epd
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+func TestGhostGuard(t *testing.T) {
+	var epd, rec = newTestEPD(16, 2)
+	epd.GhostGuardEvery = 2
+	if err := epd.Mode(PartialUpdate); err != nil {
+		t.Fatalf("Mode() failed: %v", err)
+	}
+
+	var cleaned []int
+	for i := 1; i <= 6; i++ {
+		rec.Reset()
+		var img = gray(8, 1)
+		if i%2 == 0 {
+			img = black(8, 1)
+		}
+		if err := epd.DrawRegion(img, image.Rect(0, 0, 8, 1)); err != nil {
+			t.Fatalf("DrawRegion() #%d failed: %v", i, err)
+		}
+		if len(written(rec, 0x01)) > 0 { // the clean cycle re-initialises the device
+			cleaned = append(cleaned, i)
+		}
+	}
+
+	if want := []int{2, 4, 6}; !reflect.DeepEqual(cleaned, want) {
+		t.Errorf("clean cycle ran before partial updates %v, want %v", cleaned, want)
+	}
+}
@@ -6,5 +6,5 @@ require (
 	github.com/fogleman/gg v1.3.0
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0 // indirect
 	github.com/stianeikeland/go-rpio/v4 v4.4.0
-	golang.org/x/image v0.0.0-20200921011436-3a743ba83854 // indirect
+	golang.org/x/image v0.0.0-20200921011436-3a743ba83854
 )
@@ -0,0 +1,39 @@
+package epd
+
+// GroupWriter is implemented by Interfaces that can frame a command and its data in a single transaction
+// For SPI, this means keeping the chip select asserted from the command byte until the last of its data bytes.
+type GroupWriter interface {
+	// WriteGroup sends the command byte followed by its data bytes in a single transaction
+	WriteGroup(c byte, data ...byte)
+}
+
+func (bus spi) WriteGroup(c byte, data ...byte) {
+	bus.cs.Low()
+	bus.dc.Low()
+	bus.transmit(c)
+	if len(data) > 0 {
+		bus.dc.High()
+		bus.transmit(data...)
+	}
+	bus.cs.High()
+}
+
+// grouped returns the Interface's GroupWriter if GroupWrites is enabled and the Interface supports it
+func (epd *EPD) grouped() (GroupWriter, bool) {
+	if !epd.GroupWrites {
+		return nil, false
+	}
+	var group, ok = epd.iface.(GroupWriter)
+	return group, ok
+}
+
+// record notifies the Recorder (if any) of the command and its data sent as a group
+func (epd *EPD) record(c byte, data []byte) {
+	if epd.Recorder == nil {
+		return
+	}
+	epd.Recorder.command(c)
+	for _, d := range data {
+		epd.Recorder.data(d)
+	}
+}
@@ -0,0 +1,22 @@
+package epd
+
+import "hash/fnv"
+
+// FrameHash returns the 64-bit FNV-1a hash (see hash/fnv) of the driver's frame buffer, in its packed form
+//
+// Comparing it against the hash of the next frame (packed using Pack) tells whether drawing the frame would
+// change anything, allowing the draw to be skipped. It returns zero if nothing has been drawn yet.
+// Pending changes to the frame buffer (not yet rendered using Commit) are included in the hash.
+//
+// The frame buffer holds the frame as shown on the display, with the Overlay (if any) drawn onto it, whereas Pack
+// doesn't draw the Overlay. Whilst an Overlay is set, the hash of a packed frame doesn't match FrameHash even if
+// drawing the frame would change nothing; SkipIdenticalFrames still detects such frames.
+func (epd *EPD) FrameHash() uint64 {
+	if epd.buffer == nil {
+		return 0
+	}
+
+	var h = fnv.New64a()
+	_, _ = h.Write(epd.buffer)
+	return h.Sum64()
+}
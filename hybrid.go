@@ -0,0 +1,25 @@
+package epd
+
+import "time"
+
+// HybridRefresh is the policy of drawing using partial updates for responsiveness, but promoting a draw to
+// a full update once the display hasn't had one for a while, to clear the accumulated ghosting
+//
+// The policy applies to every partial refresh, whichever method draws the frame (such as Draw, DrawDiff or
+// DrawRegion). A promoted refresh renders the frame buffer like LoadBaseImage would, leaving the device in
+// PartialUpdate mode with the frame as the base image for the following partial updates.
+// A zero field disables the respective trigger.
+type HybridRefresh struct {
+	Interval time.Duration // promote the first draw after this long since the last full update (or if there was none)
+	Partials int           // promote the draw after this many partial updates since the last full update
+}
+
+// hybridDue reports whether the next draw should be promoted to a full update as per the Hybrid policy
+func (epd *EPD) hybridDue() bool {
+	var h = epd.Hybrid
+	if h == nil || epd.mode != PartialUpdate || epd.target != None || epd.customLUT {
+		return false
+	}
+	return (h.Partials > 0 && epd.partials >= h.Partials) ||
+		(h.Interval > 0 && epd.clock().Now().Sub(epd.lastFull) >= h.Interval)
+}
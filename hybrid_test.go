@@ -0,0 +1,41 @@
+package epd
+
+import (
+	"image"
+	"testing"
+	"time"
+)
+
+func TestHybridRefresh(t *testing.T) {
+	var epd, _ = newTestEPD(16, 2)
+	epd.Hybrid = &HybridRefresh{Interval: time.Minute, Partials: 2}
+	if err := epd.Mode(PartialUpdate); err != nil {
+		t.Fatalf("Mode() failed: %v", err)
+	}
+
+	var steps = []struct {
+		name    string
+		draw    func() error
+		elapsed time.Duration // time passed before the draw
+		want    Stats         // refreshes counted after the draw
+	}{
+		{"first DrawDiff", func() error { return epd.DrawDiff(gray(16, 2)) }, 0, Stats{Full: 1}},
+		{"DrawDiff", func() error { return epd.DrawDiff(gray(16, 2, image.Pt(0, 0))) }, 0, Stats{Full: 1, Partial: 1}},
+		{"DrawRegion", func() error { return epd.DrawRegion(gray(8, 1), image.Rect(0, 0, 8, 1)) }, 0, Stats{Full: 1, Partial: 2}},
+		{"DrawDiff after 2 partials", func() error { return epd.DrawDiff(gray(16, 2, image.Pt(1, 1))) }, 0, Stats{Full: 2, Partial: 2}},
+		{"DrawRegion", func() error { return epd.DrawRegion(gray(8, 1), image.Rect(8, 0, 16, 1)) }, 0, Stats{Full: 2, Partial: 3}},
+		{"DrawRegion after the interval", func() error { return epd.DrawRegion(gray(8, 1), image.Rect(0, 1, 8, 2)) }, time.Minute, Stats{Full: 3, Partial: 3}},
+	}
+	for _, step := range steps {
+		epd.Clock.Sleep(step.elapsed)
+		if err := step.draw(); err != nil {
+			t.Fatalf("%s failed: %v", step.name, err)
+		}
+		if got := epd.UpdateStats(); got != step.want {
+			t.Errorf("UpdateStats() after %s = %+v, want %+v", step.name, got, step.want)
+		}
+		if epd.CurrentMode() != PartialUpdate {
+			t.Errorf("CurrentMode() after %s = %v, want PartialUpdate", step.name, epd.CurrentMode())
+		}
+	}
+}
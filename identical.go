@@ -0,0 +1,23 @@
+package epd
+
+import (
+	"bytes"
+	"image"
+)
+
+// ForceDraw renders the given image onto the display like Draw, refreshing the display even if the image
+// is identical to what's already shown (see SkipIdenticalFrames)
+func (epd *EPD) ForceDraw(img image.Image) error {
+	epd.force = true
+	defer func() { epd.force = false }()
+	return epd.Draw(img)
+}
+
+// identical reports whether rendering the packed frame can be skipped as the display already shows it
+// Draws onto a RAM bank (see Target) and with a one-off lookup table (see DrawWithLUT) are never skipped.
+func (epd *EPD) identical(buf []byte) bool {
+	if !epd.SkipIdenticalFrames || epd.force || epd.customLUT || epd.target != None || epd.dirty {
+		return false
+	}
+	return epd.buffer != nil && bytes.Equal(epd.buffer, buf)
+}
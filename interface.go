@@ -0,0 +1,44 @@
+package epd
+
+// Interface is the transport used to send commands and data to the device's controller
+//
+// The driver created by New uses 4-wire SPI, where the data/command pin tells the two apart. Panels wired
+// using a different interface (such as the parallel 8080 interface) can be driven by passing an implementation
+// to NewWithInterface. Reading from the device (see SetTransceive) is only supported over SPI.
+type Interface interface {
+	// WriteCommand sends a single command byte to the device
+	WriteCommand(c byte)
+
+	// WriteData sends the data bytes (the command's parameters or payload) to the device
+	WriteData(data ...byte)
+}
+
+// SPI bus configuration expected by the driver created by New
+// Adapters (and programs setting up the bus themselves) must configure the bus accordingly, as a mismatch
+// (most commonly in the word size, which some backends don't default to 8 bits) silently scrambles the output.
+const (
+	SPIBitsPerWord = 8 // every byte is sent as a single 8-bit word
+	SPIMode        = 0 // clock idle low (CPOL 0), data sampled on the leading edge (CPHA 0)
+	SPIMSBFirst    = true
+)
+
+// spi is the 4-wire SPI Interface used by New
+type spi struct {
+	dc       WriteablePin // for data/command select signal; D=HIGH C=LOW
+	cs       WriteablePin // for chip select signal; this pin is active low
+	transmit Transmit
+}
+
+func (bus spi) WriteCommand(c byte) {
+	bus.dc.Low()
+	bus.cs.Low()
+	bus.transmit(c)
+	bus.cs.High()
+}
+
+func (bus spi) WriteData(data ...byte) {
+	bus.dc.High()
+	bus.cs.Low()
+	bus.transmit(data...)
+	bus.cs.High()
+}
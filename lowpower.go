@@ -0,0 +1,37 @@
+package epd
+
+import "time"
+
+// InitPartial is a lighter alternative to Mode(PartialUpdate) for battery powered devices that only ever
+// do partial updates, typically waking up from deep sleep, updating a region and going back to sleep.
+//
+// It uses a much shorter reset pulse than Mode (unless a ResetFunc is set). On V2 panels it relies on the registers'
+// power-on defaults, sending only the partial waveform and the display option, border and power control writes
+// rather than the complete initialisation sequence; V1 panels lose their configuration (such as the GateScan,
+// waveform tuning parameters and TemperatureProfiles) on reset, so their registers are all written again.
+// The RAM isn't cleared (regardless of ClearOnInit);
+// instead the driver's frame buffer is written into it as the base image for the following partial updates
+// (see DrawBase), without refreshing the display.
+func (epd *EPD) InitPartial() error {
+	if epd.ResetFunc != nil {
+		epd.ResetFunc(epd.rst)
+	} else {
+		epd.rst.Low()
+		epd.clock().Sleep(2 * time.Millisecond)
+		epd.rst.High()
+		epd.clock().Sleep(10 * time.Millisecond)
+	}
+
+	epd.mode, epd.awake, epd.powered = PartialUpdate, true, false
+	var steps = epd.initV1(PartialUpdate)
+	if epd.Revision == V2 {
+		if err := epd.idle(); err != nil {
+			return err
+		}
+		steps = epd.partialV2()
+	}
+	if err := epd.run(steps); err != nil {
+		return err
+	}
+	return epd.rebase()
+}
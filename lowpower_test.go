@@ -0,0 +1,52 @@
+package epd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestInitPartial(t *testing.T) {
+	// V1 panels lose their registers on reset and so need them all written again, while V2 panels keep relying
+	// on their power-on defaults and only get the partial configuration
+	var tests = []struct {
+		revision     Revision
+		sent, unsent []byte
+	}{
+		{V1, []byte{0x01, 0x0C, 0x2C, 0x3A, 0x3B, 0x11, 0x32}, []byte{0x12}},
+		{V2, []byte{0x32, 0x37, 0x3C}, []byte{0x01, 0x0C, 0x11, 0x12}},
+	}
+
+	for _, test := range tests {
+		var epd, rec = newTestEPD(16, 2)
+		epd.Revision = test.revision
+		if err := epd.InitPartial(); err != nil {
+			t.Fatalf("InitPartial() on revision %d failed: %v", test.revision, err)
+		}
+
+		var sent = make(map[byte]bool)
+		for _, step := range rec.Steps() {
+			sent[step.Command] = true
+		}
+		for _, c := range test.sent {
+			if !sent[c] {
+				t.Errorf("InitPartial() on revision %d didn't send 0x%02X", test.revision, c)
+			}
+		}
+		for _, c := range test.unsent {
+			if sent[c] {
+				t.Errorf("InitPartial() on revision %d sent 0x%02X, want only the partial configuration", test.revision, c)
+			}
+		}
+		if lut := written(rec, 0x32); test.revision == V1 && !bytes.Equal(lut, epd.lut[PartialUpdate]) {
+			t.Errorf("InitPartial() on revision %d didn't load the partial lookup table", test.revision)
+		}
+
+		// the RAM isn't cleared; only the (blank) frame buffer is written into it once as the base image
+		if n, want := len(written(rec, ramBlack)), 4; n != want {
+			t.Errorf("InitPartial() on revision %d wrote %d bytes into RAM, want %d", test.revision, n, want)
+		}
+		if epd.CurrentMode() != PartialUpdate {
+			t.Errorf("CurrentMode() after InitPartial() = %v, want PartialUpdate", epd.CurrentMode())
+		}
+	}
+}
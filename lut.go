@@ -0,0 +1,43 @@
+package epd
+
+import "image"
+
+// DrawWithLUT renders the given image onto the display like Draw, but refreshes the display using the given
+// lookup table (waveform) instead of the one of the current mode, without switching modes
+//
+// The lookup table is written into the device as-is, so it must be in the format expected by the panel's
+// controller (see Revision). The mode's lookup table is restored once the image is drawn, even if drawing fails.
+// On V2 panels in FullUpdate mode, the display is refreshed without loading the waveform from OTP.
+func (epd *EPD) DrawWithLUT(img image.Image, lut []byte) error {
+	img = epd.preprocess(img)
+	if !epd.Fits(img) {
+		return ErrInvalidImageSize
+	}
+
+	epd.send(0x32, lut...)
+	if err := epd.idle(); err != nil {
+		return err
+	}
+
+	epd.customLUT = true
+	var err = epd.render(img)
+	epd.customLUT = false
+
+	if rerr := epd.restoreLUT(); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// restoreLUT writes the lookup table of the current mode back into the device
+func (epd *EPD) restoreLUT() error {
+	switch {
+	case epd.Revision != V2:
+		epd.send(0x32, epd.lut[epd.mode]...)
+	case epd.Waveforms[epd.mode] != nil:
+		return epd.run(waveformSteps(epd.Waveforms[epd.mode]))
+	default:
+		return nil // the waveform is loaded from OTP on every refresh
+	}
+	return epd.idle()
+}
@@ -0,0 +1,54 @@
+package epd
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"golang.org/x/image/font"
+)
+
+// Marquee scrolls a single line of text horizontally (right to left) across a strip of the display
+// It's meant for text longer than the display is wide. The device is expected to be in PartialUpdate mode
+// so that each step only refreshes the strip.
+type Marquee struct {
+	epd    *EPD
+	strip  image.Rectangle // region of the display the text scrolls across
+	canvas *image.Gray     // the text followed by the gap before it repeats
+	offset int             // column of the canvas shown at the left edge of the display
+}
+
+// NewMarquee creates a new Marquee scrolling the string s, drawn using the given font face, across
+// the full width of the display with the top of the text's box at y. The text repeats after a gap
+// as wide as four spaces.
+func NewMarquee(epd *EPD, s string, face font.Face, y int) (*Marquee, error) {
+	var size = MeasureText(s, face)
+	var strip = image.Rect(0, y, epd.Width, y+size.Y)
+	if s == "" || !strip.In(epd.bounds()) {
+		return nil, ErrInvalidImageSize
+	}
+
+	var canvas = image.NewGray(image.Rect(0, 0, size.X+MeasureText("    ", face).X, size.Y))
+	draw.Draw(canvas, canvas.Bounds(), image.White, image.Point{}, draw.Src)
+	text(canvas, s, face, image.Point{}, image.Black)
+	return &Marquee{epd: epd, strip: strip, canvas: canvas}, nil
+}
+
+// Advance scrolls the text left by n pixels (right if n is negative) and renders the strip
+// Once the end of the text (and the gap after it) scrolls by, the text starts over.
+func (m *Marquee) Advance(n int) error {
+	var w = m.canvas.Bounds().Dx()
+	m.offset = ((m.offset+n)%w + w) % w
+	return m.epd.DrawRegion(marqueeWindow{m}, m.strip)
+}
+
+// marqueeWindow is the strip-sized view into a marquee's canvas at its current offset
+type marqueeWindow struct{ *Marquee }
+
+func (w marqueeWindow) ColorModel() color.Model { return color.GrayModel }
+
+func (w marqueeWindow) Bounds() image.Rectangle { return w.strip }
+
+func (w marqueeWindow) At(x, y int) color.Color {
+	return w.canvas.GrayAt((w.offset+x)%w.canvas.Bounds().Dx(), y-w.strip.Min.Y)
+}
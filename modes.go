@@ -0,0 +1,26 @@
+package epd
+
+import "time"
+
+// ModeInfo describes the characteristics of a mode on the driver's panel
+type ModeInfo struct {
+	Mode Mode
+	Name string
+
+	// RefreshTime is the typical time a refresh takes in this mode, as per Waveshare's specification
+	RefreshTime time.Duration
+
+	// Flickers is set if the display flashes between black and white while refreshing in this mode
+	Flickers bool
+
+	// NeedsBase is set if the mode relies on a base image (see DrawBase) to compute which pixels to update
+	NeedsBase bool
+}
+
+// Modes returns the modes supported by the driver's panel along with their characteristics
+func (epd *EPD) Modes() []ModeInfo {
+	return []ModeInfo{
+		{Mode: FullUpdate, Name: "full", RefreshTime: 2 * time.Second, Flickers: true},
+		{Mode: PartialUpdate, Name: "partial", RefreshTime: 300 * time.Millisecond, NeedsBase: epd.Revision == V2},
+	}
+}
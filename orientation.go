@@ -0,0 +1,26 @@
+package epd
+
+import "image"
+
+// Orientation is the orientation in which the display is viewed, relative to its native (portrait) one
+type Orientation int
+
+const (
+	Portrait         Orientation = iota // the display's native orientation
+	Landscape                           // the display turned 90° clockwise
+	PortraitFlipped                     // the display turned 180°
+	LandscapeFlipped                    // the display turned 270° clockwise
+)
+
+// DrawOriented renders the given image, authored for the display viewed in the given orientation, onto the display
+//
+// The image is run through the Pipeline and then rotated into the display's native orientation, so a Landscape image
+// must be Height pixels wide and Width pixels tall. The orientation only applies to this call, making it safe to mix
+// portrait and landscape content without any shared state.
+func (epd *EPD) DrawOriented(img image.Image, orientation Orientation) error {
+	img = epd.preprocess(img)
+	if _, uniform := img.(*image.Uniform); !uniform && orientation != Portrait {
+		img = Rotate(-90 * int(orientation))(img)
+	}
+	return epd.render(img)
+}
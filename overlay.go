@@ -0,0 +1,40 @@
+package epd
+
+import (
+	"image"
+	"image/color"
+)
+
+// stamp draws the Overlay (if any) onto the packed frame, returning the region of the frame it changed
+func (epd *EPD) stamp(buf []byte) image.Rectangle {
+	if epd.Overlay == nil {
+		return image.Rectangle{}
+	}
+	var prev = append([]byte(nil), buf...)
+	epd.Overlay(sheet{epd, buf})
+	return epd.changed(prev, buf)
+}
+
+// sheet is a draw.Image backed by a packed frame (in the same layout as the frame buffer)
+// It is what the Overlay draws onto.
+type sheet struct {
+	epd *EPD
+	buf []byte
+}
+
+func (s sheet) ColorModel() color.Model { return color.GrayModel }
+
+func (s sheet) Bounds() image.Rectangle { return s.epd.bounds() }
+
+func (s sheet) At(x, y int) color.Color {
+	if !(image.Point{X: x, Y: y}.In(s.Bounds())) || !s.epd.marked(s.buf[y*s.epd.stride()+x/8], x) {
+		return color.White
+	}
+	return color.Black
+}
+
+func (s sheet) Set(x, y int, c color.Color) {
+	if (image.Point{X: x, Y: y}.In(s.Bounds())) {
+		s.epd.mark(&s.buf[y*s.epd.stride()+x/8], x, s.epd.dark(c))
+	}
+}
@@ -0,0 +1,50 @@
+package epd
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestOverlay(t *testing.T) {
+	var epd, rec = newTestEPD(16, 2)
+	epd.Overlay = func(dst draw.Image) { dst.Set(0, 0, color.Black) }
+	var stamped = []byte{0x7F, 0xFF, 0xFF, 0xFF}
+
+	var draws = []struct {
+		name string
+		draw func() error
+		ram  []byte // bytes expected to be written into the RAM
+	}{
+		{"Draw", func() error { return epd.Draw(gray(16, 2)) }, stamped},
+		{"DrawDiff", func() error { return epd.DrawDiff(gray(16, 2, image.Pt(15, 1))) }, []byte{0xFE}},
+		{"DrawRegion", func() error { return epd.DrawRegion(gray(8, 2), image.Rect(0, 0, 8, 2)) }, []byte{0x7F, 0xFF}},
+		{"DrawMono1bpp", func() error { return epd.DrawMono1bpp(gray(16, 2)) }, stamped},
+	}
+	for _, d := range draws {
+		rec.Reset()
+		if err := d.draw(); err != nil {
+			t.Fatalf("%s() failed: %v", d.name, err)
+		}
+		if ram := written(rec, ramBlack); !bytes.Equal(ram, d.ram) {
+			t.Errorf("%s() wrote % X into RAM, want % X", d.name, ram, d.ram)
+		}
+		if snapshot := epd.Snapshot(); snapshot[0] != stamped[0] {
+			t.Errorf("%s() left the frame buffer as % X, want the Overlay drawn at (0, 0)", d.name, snapshot)
+		}
+	}
+
+	// the Overlay itself never counts as a change
+	rec.Reset()
+	if err := epd.DrawDiff(gray(16, 2)); err != nil {
+		t.Fatalf("DrawDiff() failed: %v", err)
+	}
+	if !bytes.Equal(epd.Snapshot(), stamped) {
+		t.Errorf("Snapshot() = % X, want % X", epd.Snapshot(), stamped)
+	}
+	if ram := written(rec, ramBlack); len(ram) != 0 {
+		t.Errorf("DrawDiff() of an unchanged frame wrote % X into RAM, want nothing", ram)
+	}
+}
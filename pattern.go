@@ -0,0 +1,31 @@
+package epd
+
+import "image"
+
+// common 8x8 patterns for use with FillPattern
+// each byte is a row of the pattern (from the top) with the most significant bit being its left-most pixel
+var (
+	PatternGray25 = [8]byte{0x88, 0x00, 0x22, 0x00, 0x88, 0x00, 0x22, 0x00} // 25% of the pixels dark
+	PatternGray50 = [8]byte{0xAA, 0x55, 0xAA, 0x55, 0xAA, 0x55, 0xAA, 0x55} // checkerboard
+	PatternGray75 = [8]byte{0x77, 0xFF, 0xDD, 0xFF, 0x77, 0xFF, 0xDD, 0xFF} // 75% of the pixels dark
+
+	PatternHorizontal = [8]byte{0xFF, 0x00, 0x00, 0x00, 0xFF, 0x00, 0x00, 0x00} // horizontal lines
+	PatternVertical   = [8]byte{0x88, 0x88, 0x88, 0x88, 0x88, 0x88, 0x88, 0x88} // vertical lines
+	PatternDiagonal   = [8]byte{0x80, 0x40, 0x20, 0x10, 0x08, 0x04, 0x02, 0x01} // diagonal lines
+	PatternCrossHatch = [8]byte{0xFF, 0x88, 0x88, 0x88, 0xFF, 0x88, 0x88, 0x88} // grid
+)
+
+// FillPattern fills the region r of the frame buffer by tiling the given 8x8 pattern, where a set bit is a dark pixel
+//
+// The pattern is aligned to the display's origin (rather than to r) so that adjacent fills line up seamlessly.
+// It's a cheap way of shading regions compared to dithering. The region is clipped to the display and
+// is only rendered onto the display once Commit is called.
+func (epd *EPD) FillPattern(r image.Rectangle, pattern [8]byte) {
+	r = r.Intersect(epd.bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			epd.setpixel(x, y, pattern[y%8]&(0x80>>(x%8)) != 0)
+		}
+	}
+	epd.dirty = true
+}
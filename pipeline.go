@@ -0,0 +1,178 @@
+package epd
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// Stage is a single step of a Pipeline, transforming an image into another
+type Stage func(image.Image) image.Image
+
+// Pipeline is a sequence of stages applied (in order) to an image before it's packed and drawn
+type Pipeline []Stage
+
+// Apply runs the image through all the stages of the pipeline and returns the result
+func (p Pipeline) Apply(img image.Image) image.Image {
+	for _, stage := range p {
+		img = stage(img)
+	}
+	return img
+}
+
+// Scale returns a stage that resizes the image to w x h pixels using bilinear interpolation
+func Scale(w, h int) Stage {
+	return func(img image.Image) image.Image {
+		var out = image.NewRGBA(image.Rect(0, 0, w, h))
+		xdraw.BiLinear.Scale(out, out.Bounds(), img, img.Bounds(), xdraw.Src, nil)
+		return out
+	}
+}
+
+// Gamma returns a stage that converts the image to grayscale and applies gamma correction to it
+// Values of g greater than 1 brighten the mid-tones while values less than 1 darken them.
+func Gamma(g float64) Stage {
+	var lut [256]uint8
+	for i := range lut {
+		lut[i] = uint8(math.Round(255 * math.Pow(float64(i)/255, 1/g)))
+	}
+
+	return func(img image.Image) image.Image {
+		var b = img.Bounds()
+		var out = image.NewGray(b)
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				out.SetGray(x, y, color.Gray{Y: lut[luminance(img.At(x, y))]})
+			}
+		}
+		return out
+	}
+}
+
+// ContrastStretch returns a stage that converts the image to grayscale and linearly stretches its contrast,
+// mapping the darkest pixel of the image to black and the brightest one to white
+// It helps low-contrast (washed-out) images before thresholding or dithering them.
+func ContrastStretch() Stage {
+	return func(img image.Image) image.Image {
+		var b = img.Bounds()
+		var out = image.NewGray(b)
+		var min, max uint8 = 0xFF, 0x00
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				var l = luminance(img.At(x, y))
+				if l < min {
+					min = l
+				}
+				if l > max {
+					max = l
+				}
+				out.SetGray(x, y, color.Gray{Y: l})
+			}
+		}
+
+		if max <= min {
+			return out // a flat image has no contrast to stretch
+		}
+		for i, l := range out.Pix {
+			out.Pix[i] = uint8((int(l) - int(min)) * 0xFF / (int(max) - int(min)))
+		}
+		return out
+	}
+}
+
+// Dither returns a stage that converts the image to black and white using Floyd-Steinberg error diffusion
+// This preserves the perceived tones of photos far better than a plain threshold.
+func Dither() Stage {
+	return func(img image.Image) image.Image {
+		var b = img.Bounds()
+		var w, h = b.Dx(), b.Dy()
+
+		var lum = make([]float64, w*h)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				lum[y*w+x] = float64(luminance(img.At(b.Min.X+x, b.Min.Y+y)))
+			}
+		}
+
+		var out = image.NewGray(b)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				var old, val = lum[y*w+x], 0.0
+				if old >= 128 {
+					val = 255
+				}
+				out.SetGray(b.Min.X+x, b.Min.Y+y, color.Gray{Y: uint8(val)})
+
+				var e = old - val
+				if x+1 < w {
+					lum[y*w+x+1] += e * 7 / 16
+				}
+				if y+1 < h {
+					if x > 0 {
+						lum[(y+1)*w+x-1] += e * 3 / 16
+					}
+					lum[(y+1)*w+x] += e * 5 / 16
+					if x+1 < w {
+						lum[(y+1)*w+x+1] += e * 1 / 16
+					}
+				}
+			}
+		}
+		return out
+	}
+}
+
+// Invert returns a stage that inverts the colors of the image
+func Invert() Stage {
+	return func(img image.Image) image.Image {
+		var b = img.Bounds()
+		var out = image.NewRGBA(b)
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				var c = color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+				out.SetRGBA(x, y, color.RGBA{R: c.A - c.R, G: c.A - c.G, B: c.A - c.B, A: c.A})
+			}
+		}
+		return out
+	}
+}
+
+// Rotate returns a stage that rotates the image clockwise by the given angle, which must be a multiple of 90
+func Rotate(degrees int) Stage {
+	var turns = ((degrees/90)%4 + 4) % 4
+	return func(img image.Image) image.Image {
+		var b = img.Bounds()
+		var w, h = b.Dx(), b.Dy()
+
+		var out *image.RGBA
+		if turns%2 == 1 {
+			out = image.NewRGBA(image.Rect(0, 0, h, w))
+		} else {
+			out = image.NewRGBA(image.Rect(0, 0, w, h))
+		}
+
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				var c = img.At(b.Min.X+x, b.Min.Y+y)
+				switch turns {
+				case 0:
+					out.Set(x, y, c)
+				case 1:
+					out.Set(h-1-y, x, c)
+				case 2:
+					out.Set(w-1-x, h-1-y, c)
+				case 3:
+					out.Set(y, w-1-x, c)
+				}
+			}
+		}
+		return out
+	}
+}
+
+// luminance returns the luminance of the color as an 8-bit value
+func luminance(c color.Color) uint8 {
+	return color.GrayModel.Convert(c).(color.Gray).Y
+}
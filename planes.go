@@ -0,0 +1,29 @@
+package epd
+
+// SetBlackPlane writes a packed black/white plane directly into the device's RAM without refreshing the display
+//
+// The buffer must be in the layout described by DrawBuffer. Call Commit to render the planes onto the display.
+func (epd *EPD) SetBlackPlane(buf []byte) error {
+	if len(buf) != epd.stride()*epd.Height {
+		return ErrInvalidBufferSize
+	}
+
+	buf = append([]byte(nil), buf...)
+	if err := epd.write(ramBlack, buf); err != nil {
+		return err
+	}
+	epd.buffer, epd.dirty = buf, false
+	return nil
+}
+
+// SetRedPlane writes a packed red plane directly into the device's RAM without refreshing the display
+//
+// The buffer uses the same layout as the black plane, with the bits in the panel's native red polarity.
+// It is only meaningful on tri-color panels. Call Commit to render the planes onto the display.
+func (epd *EPD) SetRedPlane(buf []byte) error {
+	if len(buf) != epd.stride()*epd.Height {
+		return ErrInvalidBufferSize
+	}
+
+	return epd.write(ramRed, buf)
+}
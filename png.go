@@ -0,0 +1,23 @@
+package epd
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// WritePNG encodes the driver's frame buffer (ie. what the driver has drawn onto the display) as a black and white
+// PNG image and writes it to w. It's useful for remotely monitoring what a headless device is showing.
+// Pending changes to the frame buffer (not yet rendered using Commit) are included in the image.
+func (epd *EPD) WritePNG(w io.Writer) error {
+	var img = image.NewPaletted(epd.bounds(), color.Palette{color.White, color.Black})
+	for y := 0; y < epd.Height; y++ {
+		for x := 0; x < epd.Width; x++ {
+			if epd.pixel(x, y) {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+	return png.Encode(w, img)
+}
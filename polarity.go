@@ -0,0 +1,32 @@
+package epd
+
+// Polarity defines the meaning of the bits of a packed frame, ie. the value of the bit denoting a dark pixel
+// This is about the format the device expects on the wire and is distinct from inverting the image's colors.
+type Polarity uint8
+
+const (
+	DarkIsZero Polarity = iota // a 0 bit denotes a dark pixel; the default
+	DarkIsOne                  // a 1 bit denotes a dark pixel
+)
+
+// white returns the value of a packed byte holding 8 light pixels
+func (epd *EPD) white() byte {
+	if epd.BitPolarity == DarkIsOne {
+		return 0x00
+	}
+	return 0xFF
+}
+
+// marked reports whether the pixel at x within the packed byte b is dark
+func (epd *EPD) marked(b byte, x int) bool {
+	return (b&epd.bit(x) != 0) == (epd.BitPolarity == DarkIsOne)
+}
+
+// mark sets the pixel at x within the packed byte b to either dark or light
+func (epd *EPD) mark(b *byte, x int, dark bool) {
+	if dark == (epd.BitPolarity == DarkIsOne) {
+		*b |= epd.bit(x)
+	} else {
+		*b &= ^epd.bit(x)
+	}
+}
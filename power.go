@@ -0,0 +1,47 @@
+package epd
+
+// bits of DISPLAY_UPDATE_CONTROL_2 (0x22) powering the analog circuitry (and the clock) up and down
+const (
+	powerUp   byte = 0xC0 // enable clock and analog
+	powerDown byte = 0x03 // disable analog and clock
+)
+
+// PowerOn powers the device's analog circuitry up and keeps it powered until PowerOff (or Sleep) is called
+//
+// Normally every refresh powers the analog circuitry up before updating the display and down afterwards.
+// For rapid consecutive partial updates that's a significant overhead, which is avoided by calling PowerOn once
+// before the updates and PowerOff after them. Re-initialising the device (using Mode) powers it down again.
+func (epd *EPD) PowerOn() error {
+	epd.send(0x22, powerUp)
+	epd.command(0x20)
+	if err := epd.idle(); err != nil {
+		return err
+	}
+	epd.powered = true
+	return nil
+}
+
+// PowerOff powers the device's analog circuitry down after a call to PowerOn
+func (epd *EPD) PowerOff() error {
+	epd.powered = false
+	epd.send(0x22, powerDown)
+	epd.command(0x20)
+	return epd.idle()
+}
+
+// sequence returns the DISPLAY_UPDATE_CONTROL_2 sequence used to refresh the display in the current mode
+// While the analog circuitry is kept powered (see PowerOn), the sequence leaves out powering it up and down.
+// V2 panels load the waveform from OTP unless one is uploaded for the mode (see Waveforms) or DrawWithLUT is used.
+func (epd *EPD) sequence() byte {
+	var seq byte = 0xC4
+	if epd.Revision == V2 {
+		seq = sequenceV2[epd.mode]
+		if !epd.customLUT && epd.Waveforms[epd.mode] == nil {
+			seq |= loadOTP
+		}
+	}
+	if epd.powered {
+		seq &^= powerUp | powerDown
+	}
+	return seq
+}
@@ -0,0 +1,65 @@
+package epd
+
+import "image"
+
+// fill sets all the pixels in the region r of the frame buffer to either dark or light
+func (epd *EPD) fill(r image.Rectangle, dark bool) {
+	r = r.Intersect(epd.bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			epd.setpixel(x, y, dark)
+		}
+	}
+	epd.dirty = true
+}
+
+// stroke draws a dark outline of the given width along the inside of the region r onto the frame buffer
+func (epd *EPD) stroke(r image.Rectangle, width int) {
+	var in = r.Inset(width)
+	epd.fill(image.Rect(r.Min.X, r.Min.Y, r.Max.X, in.Min.Y), true)   // top
+	epd.fill(image.Rect(r.Min.X, in.Max.Y, r.Max.X, r.Max.Y), true)   // bottom
+	epd.fill(image.Rect(r.Min.X, in.Min.Y, in.Min.X, in.Max.Y), true) // left
+	epd.fill(image.Rect(in.Max.X, in.Min.Y, r.Max.X, in.Max.Y), true) // right
+}
+
+// line draws a dark line from p0 to p1 (both inclusive) onto the frame buffer, clipped to the region clip
+// It uses Bresenham's line algorithm.
+func (epd *EPD) line(p0, p1 image.Point, clip image.Rectangle) {
+	clip = clip.Intersect(epd.bounds())
+
+	var dx, dy = abs(p1.X - p0.X), -abs(p1.Y - p0.Y)
+	var sx, sy = sign(p1.X - p0.X), sign(p1.Y - p0.Y)
+	for err := dx + dy; ; {
+		if p0.In(clip) {
+			epd.setpixel(p0.X, p0.Y, true)
+		}
+		if p0 == p1 {
+			break
+		}
+		if e2 := 2 * err; e2 >= dy {
+			err += dy
+			p0.X += sx
+		} else {
+			err += dx
+			p0.Y += sy
+		}
+	}
+	epd.dirty = true
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	}
+	return 0
+}
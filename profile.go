@@ -0,0 +1,94 @@
+package epd
+
+// Profile bundles the parameters specific to a panel model
+// Use NewFromProfile to create a driver configured for the panel, or define a new Profile for panels not listed
+// in Profiles that share the command set of one of the supported controllers (see Revision).
+type Profile struct {
+	Name string
+
+	// dimensions of the display
+	Width  int
+	Height int
+
+	// Revision selects the controller's command set and hence the initialisation sequence used
+	Revision Revision
+
+	// waveform tuning parameters and lookup tables used in full and partial update modes (only used by V1 panels)
+	DummyLinePeriod byte
+	GateTime        byte
+	FullLUT         []byte
+	PartialLUT      []byte
+
+	// BusyLevel is the level (0 or 1) of the busy pin while the device is busy
+	BusyLevel uint8
+}
+
+// Profiles holds the profiles of the panels known to work with this driver
+var Profiles = struct {
+	WS29   Profile // Waveshare 2.9" (IL3820)
+	WS29V2 Profile // Waveshare 2.9" V2 (SSD1680)
+	WS213  Profile // Waveshare 2.13" (IL3895)
+	WS154  Profile // Waveshare 1.54" (IL3829)
+}{
+	WS29: Profile{
+		Name:  "Waveshare 2.9inch e-Paper",
+		Width: 128, Height: 296,
+		Revision:        V1,
+		DummyLinePeriod: 0x1A, GateTime: 0x08,
+		FullLUT: fullUpdate, PartialLUT: partialUpdate,
+		BusyLevel: 0x1,
+	},
+
+	WS29V2: Profile{
+		Name:  "Waveshare 2.9inch e-Paper V2",
+		Width: 128, Height: 296,
+		Revision:  V2,
+		BusyLevel: 0x1,
+	},
+
+	WS213: Profile{
+		Name:  "Waveshare 2.13inch e-Paper",
+		Width: 122, Height: 250,
+		Revision:        V1,
+		DummyLinePeriod: 0x1B, GateTime: 0x0B,
+		FullLUT: []byte{
+			0x22, 0x55, 0xAA, 0x55, 0xAA, 0x55, 0xAA, 0x11,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x1E, 0x1E, 0x1E, 0x1E, 0x1E, 0x1E, 0x1E, 0x1E,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		PartialLUT: []byte{
+			0x18, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x0F, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		BusyLevel: 0x1,
+	},
+
+	WS154: Profile{
+		Name:  "Waveshare 1.54inch e-Paper",
+		Width: 200, Height: 200,
+		Revision:        V1,
+		DummyLinePeriod: 0x1A, GateTime: 0x08,
+		FullLUT: []byte{
+			0x02, 0x02, 0x01, 0x11, 0x12, 0x12, 0x22, 0x22,
+			0x66, 0x69, 0x69, 0x59, 0x58, 0x99, 0x99, 0x88,
+			0x00, 0x00, 0x00, 0x00, 0xF8, 0xB4, 0x13, 0x51,
+			0x35, 0x51, 0x51, 0x19, 0x01, 0x00,
+		},
+		PartialLUT: partialUpdate, // same as the 2.9" panel's
+		BusyLevel:  0x1,
+	},
+}
+
+// NewFromProfile creates a new EPD device driver configured for the panel described by the given profile
+func NewFromProfile(profile Profile, rst, dc, cs WriteablePin, busy ReadablePin, transmit Transmit) *EPD {
+	var epd = New(rst, dc, cs, busy, transmit)
+	epd.Width, epd.Height = profile.Width, profile.Height
+	epd.Revision = profile.Revision
+	epd.DummyLinePeriod, epd.GateTime = profile.DummyLinePeriod, profile.GateTime
+	epd.lut = map[Mode][]byte{FullUpdate: profile.FullLUT, PartialUpdate: profile.PartialLUT}
+	epd.busyLevel = profile.BusyLevel
+	return epd
+}
@@ -0,0 +1,53 @@
+package epd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestProfileWS154(t *testing.T) {
+	var epd = NewFromProfile(Profiles.WS154, nop{}, nop{}, nop{}, nop{}, func(...byte) {})
+	epd.Clock = &fakeClock{now: time.Unix(0, 0)}
+	if err := epd.Mode(FullUpdate); err != nil {
+		t.Fatalf("Mode() failed: %v", err)
+	}
+
+	var rec = &Recorder{}
+	epd.Recorder = rec
+	if err := epd.Draw(black(200, 200)); err != nil {
+		t.Fatalf("Draw() failed: %v", err)
+	}
+
+	// the window spans all the 25 bytes of a row (x is addressed in bytes) and all the 200 rows
+	if got, want := written(rec, 0x44), []byte{0x00, 0x18}; !bytes.Equal(got, want) {
+		t.Errorf("RAM X window is % X, want % X", got, want)
+	}
+	if got, want := written(rec, 0x45), []byte{0x00, 0x00, 0xC7, 0x00}; !bytes.Equal(got, want) {
+		t.Errorf("RAM Y window is % X, want % X", got, want)
+	}
+
+	// each row is written at the start of the row, transmitting a stride of 25 bytes
+	var steps, rows, total = rec.Steps(), 0, 0
+	for i, step := range steps {
+		if step.Command != 0x24 {
+			continue
+		}
+		if i < 2 || steps[i-2].Command != 0x4E || steps[i-1].Command != 0x4F {
+			t.Fatalf("row %d written without setting the cursor", rows)
+		}
+		if x, y := steps[i-2].Data, steps[i-1].Data; !bytes.Equal(x, []byte{0x00}) || !bytes.Equal(y, []byte{byte(rows), 0x00}) {
+			t.Errorf("row %d written at cursor % X / % X, want 00 / %02X 00", rows, x, y, rows)
+		}
+		if len(step.Data) != 25 {
+			t.Errorf("row %d is %d bytes long, want 25", rows, len(step.Data))
+		}
+		rows, total = rows+1, total+len(step.Data)
+	}
+	if rows != 200 || total != 5000 {
+		t.Errorf("frame written as %d rows of %d bytes in total, want 200 rows of 5000 bytes", rows, total)
+	}
+	if ram := written(rec, 0x24); bytes.Count(ram, []byte{0x00}) != len(ram) {
+		t.Errorf("frame of a black image isn't all 0x00")
+	}
+}
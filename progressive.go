@@ -0,0 +1,25 @@
+package epd
+
+import "image"
+
+// progressiveBands is the number of bands a frame is revealed in when drawing progressively
+const progressiveBands = 8
+
+// reveal writes the packed frame into the device's RAM band by band from top to bottom,
+// refreshing the display after each band (see Progressive)
+func (epd *EPD) reveal(buf []byte) error {
+	epd.buffer, epd.dirty = buf, false
+
+	var h = (epd.Height + progressiveBands - 1) / progressiveBands
+	for y := 0; y < epd.Height; y += h {
+		var band = image.Rect(0, y, epd.Width, y+h).Intersect(epd.bounds())
+		if err := epd.writeRect(ramBlack, buf, band); err != nil {
+			epd.dirty = true // the frame buffer is now ahead of the device
+			return err
+		}
+		if err := epd.turnOnDisplay(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package epd
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+
+	// decoders for the formats supported by DrawReader
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// ErrDecodeImage is returned (wrapped along with the decoder's error) by DrawReader if the image can't be decoded
+var ErrDecodeImage = errors.New("cannot decode image")
+
+// DrawReader decodes an image (in any of the formats registered with the image package, including PNG,
+// JPEG and GIF) from the reader and renders it at the center of the display over the Background.
+//
+// The decoded image is run through the configured Pipeline first. If it's still larger than the display,
+// it's then scaled down (preserving its aspect ratio) to fit. Errors from decoding the image wrap ErrDecodeImage.
+func (epd *EPD) DrawReader(r io.Reader) error {
+	var img, _, err = image.Decode(r)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDecodeImage, err)
+	}
+
+	img = epd.fit(epd.preprocess(img))
+	var size = img.Bounds().Size()
+	var min = image.Pt((epd.Width-size.X)/2, (epd.Height-size.Y)/2)
+	return epd.render(placed{img, image.Rectangle{Min: min, Max: min.Add(size)}, epd.bounds(), epd.background()})
+}
+
+// fit scales the image down (preserving its aspect ratio) to fit within the display, if it's larger than it
+func (epd *EPD) fit(img image.Image) image.Image {
+	var size = img.Bounds().Size()
+	if size.X <= epd.Width && size.Y <= epd.Height {
+		return img
+	}
+
+	var w, h = epd.Width, size.Y * epd.Width / size.X
+	if h > epd.Height {
+		w, h = size.X*epd.Height/size.Y, epd.Height
+	}
+	var out = image.NewRGBA(image.Rect(0, 0, w, h))
+	xdraw.BiLinear.Scale(out, out.Bounds(), img, img.Bounds(), xdraw.Src, nil)
+	return out
+}
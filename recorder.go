@@ -0,0 +1,109 @@
+package epd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// opcodes maps the commands used by this driver to their names in the datasheet / reference driver
+var opcodes = map[byte]string{
+	0x01: "DRIVER_OUTPUT_CONTROL",
+	0x03: "GATE_DRIVING_VOLTAGE",
+	0x04: "SOURCE_DRIVING_VOLTAGE",
+	0x0C: "BOOSTER_SOFT_START_CONTROL",
+	0x10: "DEEP_SLEEP_MODE",
+	0x11: "DATA_ENTRY_MODE_SETTING",
+	0x12: "SW_RESET",
+	0x1B: "TEMPERATURE_SENSOR_CONTROL_READ",
+	0x20: "MASTER_ACTIVATION",
+	0x21: "DISPLAY_UPDATE_CONTROL_1",
+	0x22: "DISPLAY_UPDATE_CONTROL_2",
+	0x24: "WRITE_RAM",
+	0x26: "WRITE_RAM_RED",
+	0x2C: "WRITE_VCOM_REGISTER",
+	0x32: "WRITE_LUT_REGISTER",
+	0x37: "WRITE_REGISTER_FOR_DISPLAY_OPTION",
+	0x3A: "SET_DUMMY_LINE_PERIOD",
+	0x3B: "SET_GATE_TIME",
+	0x3C: "BORDER_WAVEFORM_CONTROL",
+	0x3F: "END_OPTION",
+	0x44: "SET_RAM_X_ADDRESS_START_END_POSITION",
+	0x45: "SET_RAM_Y_ADDRESS_START_END_POSITION",
+	0x4E: "SET_RAM_X_ADDRESS_COUNTER",
+	0x4F: "SET_RAM_Y_ADDRESS_COUNTER",
+	0xFF: "TERMINATE_FRAME_READ_WRITE",
+}
+
+// Step is a single command sent to the device along with its data payload
+// Steps are used both to record the command stream and to declare the driver's initialisation sequences.
+type Step struct {
+	Command byte
+	Data    []byte
+
+	// Wait is set if the driver waits for the device to get into idle state after this step
+	Wait bool
+}
+
+// Recorder records the command stream sent to the device by the driver
+// Set it as the driver's Recorder to start recording.
+type Recorder struct {
+	steps []Step
+}
+
+// Steps returns the recorded steps
+func (rec *Recorder) Steps() []Step {
+	return rec.steps
+}
+
+// Reset discards all the recorded steps
+func (rec *Recorder) Reset() {
+	rec.steps = nil
+}
+
+// Dump writes the recorded steps to w in a human-readable form, one command per line
+func (rec *Recorder) Dump(w io.Writer) error {
+	for _, step := range rec.steps {
+		var line strings.Builder
+		fmt.Fprintf(&line, "0x%02X", step.Command)
+		if name, ok := opcodes[step.Command]; ok {
+			fmt.Fprintf(&line, " %s", name)
+		}
+		for _, d := range step.Data {
+			fmt.Fprintf(&line, " %02X", d)
+		}
+		if step.Wait {
+			line.WriteString(" (wait)")
+		}
+		line.WriteString("\n")
+
+		if _, err := io.WriteString(w, line.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Replay sends the recorded steps to the device driven by epd, waiting for it wherever the recording did
+// Replay doesn't reset the device and doesn't update the driver's state (such as its frame buffer).
+func (rec *Recorder) Replay(epd *EPD) error {
+	return epd.run(rec.steps)
+}
+
+func (rec *Recorder) command(c byte) {
+	rec.steps = append(rec.steps, Step{Command: c})
+}
+
+func (rec *Recorder) data(d byte) {
+	if len(rec.steps) == 0 {
+		return // data without a command; nothing to attach it to
+	}
+	var last = &rec.steps[len(rec.steps)-1]
+	last.Data = append(last.Data, d)
+}
+
+func (rec *Recorder) wait() {
+	if len(rec.steps) > 0 {
+		rec.steps[len(rec.steps)-1].Wait = true
+	}
+}
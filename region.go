@@ -0,0 +1,72 @@
+package epd
+
+import "image"
+
+// DrawRegion renders the given image onto the region r of the display, leaving the rest of the display untouched
+// It is meant to be used in PartialUpdate mode.
+//
+// Inside a batch (see Begin) the region is only drawn onto the frame buffer and transmitted by End.
+//
+// The region doesn't need to be aligned to the 8-pixel boundaries of the device's RAM. Pixels in the edge bytes
+// that fall outside of r are merged in from the driver's frame buffer, so the rest of the display
+// should have been drawn by the driver beforehand.
+//
+// The region may extend past the edges of the display (eg. for objects sliding off of it), in which case only
+// the part of the image within the display is drawn. A region entirely outside of the display draws nothing.
+func (epd *EPD) DrawRegion(img image.Image, r image.Rectangle) error {
+	var _, uniform = img.(*image.Uniform) // special case for uniform images which have infinite bound
+	if r.Empty() || (!uniform && img.Bounds().Size() != r.Size()) {
+		return ErrInvalidImageSize
+	}
+
+	var off = img.Bounds().Min.Sub(r.Min)
+	if r = r.Intersect(epd.bounds()); r.Empty() {
+		return nil // nothing visible to draw
+	}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			epd.setpixel(x, y, epd.dark(img.At(x+off.X, y+off.Y)))
+		}
+	}
+	return epd.update(r)
+}
+
+// update renders the region r of the frame buffer onto the display (or records it, inside of a batch)
+func (epd *EPD) update(r image.Rectangle) error {
+	if epd.batch != nil {
+		epd.batch = append(epd.batch, r)
+		return nil // transmitted by End
+	}
+	r = r.Union(epd.stamp(epd.framebuffer())) // the region may have covered the Overlay
+
+	var err error
+	if epd.dirty { // the device is behind the frame buffer (eg. cleared by ClearOnInit); bring all of it up to date
+		err = epd.flush()
+	} else {
+		err = epd.writeRect(ramBlack, epd.buffer, r)
+	}
+	if err != nil {
+		epd.dirty = true // the frame buffer is now ahead of the device
+		return err
+	}
+	return epd.turnOnDisplay()
+}
+
+// DrawRegionAndSleep wakes the device up (if it's in deep sleep), renders the image onto the region r
+// of the display like DrawRegion and puts the device back into deep sleep, minimising the time it's powered.
+//
+// When woken up, the device is initialised into PartialUpdate mode and the driver's frame buffer is written
+// into its RAM so that the rest of the display is preserved. The device is put to sleep even if drawing fails.
+func (epd *EPD) DrawRegionAndSleep(img image.Image, r image.Rectangle) error {
+	defer epd.Sleep()
+
+	if !epd.awake {
+		if err := epd.Mode(PartialUpdate); err != nil {
+			return err
+		}
+		if err := epd.rebase(); err != nil {
+			return err
+		}
+	}
+	return epd.DrawRegion(img, r)
+}
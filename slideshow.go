@@ -0,0 +1,40 @@
+package epd
+
+import (
+	"context"
+	"image"
+	"time"
+)
+
+// Slideshow cycles through the given images, rendering each one using a full update and putting the device
+// into deep sleep in between the frames to save power. The device is woken up (reset and re-initialised) before
+// rendering the next frame.
+//
+// Slideshow runs until the context is cancelled (in which case it returns the context's error)
+// or until an image fails to render. The device is always left in deep sleep when Slideshow returns.
+// The pauses between the frames are slept on the driver's Clock and cut short by cancelling the context
+// (for a custom Clock, only if it's a ContextClock).
+func (epd *EPD) Slideshow(ctx context.Context, imgs []image.Image, interval time.Duration) error {
+	if len(imgs) == 0 {
+		return nil // nothing to show
+	}
+
+	for i := 0; ; i = (i + 1) % len(imgs) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var err = epd.Mode(FullUpdate) // wakes the device up from deep sleep
+		if err == nil {
+			err = epd.Draw(imgs[i])
+		}
+		epd.Sleep()
+		if err != nil {
+			return err
+		}
+
+		if err := epd.sleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package epd
+
+import (
+	"context"
+	"image"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pauseClock is a Clock that returns immediately from every Sleep, cancelling a context after a number of
+// pauses of the given interval; it's safe for concurrent use
+type pauseClock struct {
+	mu       sync.Mutex
+	interval time.Duration
+	pauses   int
+	cancel   context.CancelFunc
+}
+
+func (c *pauseClock) Now() time.Time { return time.Unix(0, 0) }
+
+func (c *pauseClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d == c.interval {
+		if c.pauses--; c.pauses == 0 {
+			c.cancel()
+		}
+	}
+}
+
+func TestSlideshowCancel(t *testing.T) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var epd, _ = newTestEPD(16, 2)
+	epd.Clock = nil // the real clock, whose pause must be cut short
+	epd.ResetFunc = func(WriteablePin) {}
+	epd.OnAfterRefresh = cancel
+
+	var done = make(chan error)
+	go func() { done <- epd.Slideshow(ctx, []image.Image{gray(16, 2)}, time.Hour) }()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("Slideshow() returned %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Slideshow() kept pausing after the context was cancelled")
+	}
+}
+
+func TestSlideshow(t *testing.T) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var epd, rec = newTestEPD(16, 2)
+	epd.ResetFunc = func(WriteablePin) {}
+	epd.Clock = &pauseClock{interval: time.Hour, pauses: 3, cancel: cancel}
+
+	var imgs = []image.Image{gray(16, 2), black(16, 2)}
+	if err := epd.Slideshow(ctx, imgs, time.Hour); err != context.Canceled {
+		t.Fatalf("Slideshow() returned %v, want context.Canceled", err)
+	}
+
+	// the pauses are slept on the driver's clock: three frames are shown (each followed by deep sleep)
+	// before the context is cancelled
+	if frames := len(written(rec, 0x10)); frames != 3 {
+		t.Errorf("Slideshow() showed %d frames, want 3", frames)
+	}
+	if epd.awake {
+		t.Errorf("Slideshow() returned without putting the device into deep sleep")
+	}
+}
@@ -0,0 +1,33 @@
+package epd
+
+import "image"
+
+// DrawBytesAt renders a precomputed packed bitmap onto the region r of the display, without any image conversion
+//
+// The bitmap must be packed in the device's native format (see DrawBuffer) with a stride of ceil(width/8) bytes,
+// where width is that of r, and r must lie within the display with its left edge on an 8-pixel boundary.
+// If r's width isn't a multiple of 8, the unused bits at the end of each row are ignored. Like DrawRegion,
+// it is meant to be used in PartialUpdate mode and takes part in batches (see Begin).
+func (epd *EPD) DrawBytesAt(buf []byte, r image.Rectangle) error {
+	if r.Empty() || !r.In(epd.bounds()) || r.Min.X%8 != 0 {
+		return ErrInvalidImageSize
+	}
+	var n = (r.Dx() + 7) / 8
+	if len(buf) != n*r.Dy() {
+		return ErrInvalidBufferSize
+	}
+
+	// mask of the bits of the last byte of each row that are covered by r
+	var last byte
+	for x := (n - 1) * 8; x < r.Dx(); x++ {
+		last |= epd.bit(x)
+	}
+
+	var fb, stride = epd.framebuffer(), epd.stride()
+	for y := 0; y < r.Dy(); y++ {
+		var row = fb[(r.Min.Y+y)*stride+r.Min.X/8:]
+		copy(row[:n-1], buf[y*n:])
+		row[n-1] = row[n-1]&^last | buf[y*n+n-1]&last
+	}
+	return epd.update(r)
+}
@@ -0,0 +1,34 @@
+package epd
+
+// State is the part of the driver's state that can be persisted across restarts of the program
+// It is a plain struct so that it can be stored using any encoding (such as encoding/json).
+type State struct {
+	Mode   Mode   // last mode the device was initialised into
+	Awake  bool   // whether the device was initialised and not put into deep sleep afterwards
+	Buffer []byte // the driver's frame buffer (see Snapshot)
+	Stats  Stats  // refreshes performed so far (see UpdateStats)
+}
+
+// State returns the driver's current state, to be persisted and later passed on to Resume
+func (epd *EPD) State() State {
+	return State{Mode: epd.mode, Awake: epd.awake, Buffer: epd.Snapshot(), Stats: epd.stats}
+}
+
+// Resume restores a previously saved state and makes sure the device is initialised into the given mode
+//
+// If the saved state shows the device was left initialised in the same mode (and not put to sleep),
+// the reset and initialisation are skipped, preserving the image on the display without a flash.
+// Otherwise the device is initialised using Mode as usual.
+func (epd *EPD) Resume(state State, mode Mode) error {
+	if len(state.Buffer) == epd.stride()*epd.Height {
+		epd.buffer, epd.dirty = append([]byte(nil), state.Buffer...), false
+	}
+
+	epd.stats = state.Stats
+
+	if state.Awake && state.Mode == mode {
+		epd.mode, epd.awake = mode, true
+		return nil
+	}
+	return epd.Mode(mode)
+}
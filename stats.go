@@ -0,0 +1,34 @@
+package epd
+
+import "log"
+
+// Stats counts the refreshes performed by the driver
+type Stats struct {
+	Full    uint64 // number of full updates
+	Partial uint64 // number of partial updates
+}
+
+// Total returns the total number of refreshes
+func (s Stats) Total() uint64 { return s.Full + s.Partial }
+
+// UpdateStats returns the number of refreshes performed so far
+//
+// Panels degrade with every refresh, so these can be used to estimate the remaining lifetime of a panel.
+// To count over the panel's lifetime rather than the program's, persist them using State and Resume.
+func (epd *EPD) UpdateStats() Stats {
+	return epd.stats
+}
+
+// count records a refresh in the current mode, warning once the UpdateBudget is exceeded
+func (epd *EPD) count() {
+	if epd.mode == FullUpdate {
+		epd.stats.Full++
+		epd.lastFull = epd.clock().Now()
+	} else {
+		epd.stats.Partial++
+	}
+
+	if epd.UpdateBudget > 0 && epd.stats.Total() == epd.UpdateBudget+1 {
+		log.Printf("[WARN] epd: panel has exceeded its update budget of %d refreshes", epd.UpdateBudget)
+	}
+}
@@ -0,0 +1,74 @@
+package epd
+
+import (
+	"image"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// Tabular returns a font face that draws the digits of the given face with tabular figures: every digit takes up
+// the advance of the widest one, with its glyph centered within it. Anything other than digits is drawn as is.
+//
+// Use it with any of the text helpers (such as DrawTextAligned with AlignRight) to render numeric readouts that
+// don't jitter as their values change, so that "11:11" and "10:00" line up and only the changed digits need redrawing.
+func Tabular(face font.Face) font.Face {
+	var t = tabular{Face: face}
+	for r := '0'; r <= '9'; r++ {
+		if adv, ok := face.GlyphAdvance(r); ok && adv > t.advance {
+			t.advance = adv
+		}
+	}
+	return t
+}
+
+// tabular is a font face with fixed-advance digits (see Tabular)
+type tabular struct {
+	font.Face
+	advance fixed.Int26_6 // advance of the widest digit
+}
+
+// offset returns the horizontal offset that centers the glyph of a digit (with the given advance) within its cell
+func (t tabular) offset(advance fixed.Int26_6) fixed.Int26_6 {
+	return (t.advance - advance) / 2
+}
+
+func (t tabular) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	if !digit(r) {
+		return t.Face.Glyph(dot, r)
+	}
+	var adv, _ = t.Face.GlyphAdvance(r)
+	dot.X += t.offset(adv)
+	var dr, mask, maskp, _, ok = t.Face.Glyph(dot, r)
+	return dr, mask, maskp, t.advance, ok
+}
+
+func (t tabular) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	var bounds, adv, ok = t.Face.GlyphBounds(r)
+	if !digit(r) {
+		return bounds, adv, ok
+	}
+	var off = t.offset(adv)
+	bounds.Min.X, bounds.Max.X = bounds.Min.X+off, bounds.Max.X+off
+	return bounds, t.advance, ok
+}
+
+func (t tabular) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	if !digit(r) {
+		return t.Face.GlyphAdvance(r)
+	}
+	var _, ok = t.Face.GlyphAdvance(r)
+	return t.advance, ok
+}
+
+func (t tabular) Kern(r0, r1 rune) fixed.Int26_6 {
+	if digit(r0) || digit(r1) {
+		return 0 // kerning would break the alignment of the digits
+	}
+	return t.Face.Kern(r0, r1)
+}
+
+// digit reports whether r is an (ASCII) digit
+func digit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
@@ -0,0 +1,68 @@
+package epd
+
+import "log"
+
+// TemperatureProfile holds the waveform parameters used below a certain ambient temperature
+//
+// Panels might fail to refresh reliably at temperature extremes with the default parameters, which are tuned
+// for room temperature. Profiles are checked in order and the first one whose Below is greater than
+// the temperature is used, so they should be sorted by Below in ascending order.
+//
+// Profiles only apply to V1 panels, whose initialisation sets the booster and gate time; V2 panels pick their
+// waveform for the temperature measured by their built-in sensor on their own. As V1 panels have no sensor
+// that can be read, the temperature has to be supplied by the caller (see EPD.Temperature), eg. from an external
+// sensor.
+type TemperatureProfile struct {
+	Below float64 // temperature (in °C) below which the profile applies
+
+	Booster  [3]byte // values for BOOSTER_SOFT_START_CONTROL (0x0C)
+	GateTime byte    // value for SET_GATE_TIME (0x3B)
+}
+
+// temperatureProfile returns the profile matching the current temperature, if there's any
+// If the temperature can't be read, the default parameters are used.
+func (epd *EPD) temperatureProfile() (TemperatureProfile, bool) {
+	if len(epd.TemperatureProfiles) == 0 || epd.Temperature == nil {
+		return TemperatureProfile{}, false
+	}
+
+	var t, err = epd.Temperature()
+	if err != nil {
+		log.Printf("[WARN] epd: failed to read temperature; using default parameters: %v", err)
+		return TemperatureProfile{}, false
+	}
+	for _, p := range epd.TemperatureProfiles {
+		if t < p.Below {
+			return p, true
+		}
+	}
+	return TemperatureProfile{}, false
+}
+
+// ReadTemperature measures the temperature (in °C) using the panel's built-in sensor
+//
+// It's only supported by V2 panels, and needs reading from the device to be set up (see SetTransceive);
+// otherwise it returns ErrReadUnsupported.
+func (epd *EPD) ReadTemperature() (float64, error) {
+	if epd.Revision != V2 {
+		return 0, ErrReadUnsupported
+	}
+
+	var seq byte = 0xA1 // enable clock, load temperature, disable clock
+	if epd.powered {
+		seq &^= powerUp | powerDown
+	}
+	epd.send(0x22, seq) // DISPLAY_UPDATE_CONTROL_2
+	epd.command(0x20)   // MASTER_ACTIVATION
+	if err := epd.idle(); err != nil {
+		return 0, err
+	}
+
+	var data, err = epd.read(0x1B, 2) // TEMPERATURE_SENSOR_CONTROL (read from temperature register)
+	if err != nil {
+		return 0, err
+	}
+	// the register holds a 12-bit two's complement value in 1/16 °C, left aligned in the two bytes
+	var raw = int16(uint16(data[0])<<8|uint16(data[1])) >> 4
+	return float64(raw) / 16, nil
+}
@@ -0,0 +1,33 @@
+package epd
+
+import "testing"
+
+func TestReadTemperature(t *testing.T) {
+	var tests = []struct {
+		register []byte
+		want     float64
+	}{
+		{[]byte{0x19, 0x00}, 25},
+		{[]byte{0x00, 0x80}, 0.5},
+		{[]byte{0xFF, 0x80}, -0.5},
+	}
+	for _, tt := range tests {
+		var epd = New(nop{}, nop{}, nop{}, nop{}, func(...byte) {})
+		epd.Revision = V2
+		epd.SetTransceive(func(write []byte, n int) ([]byte, error) { return tt.register[:n], nil })
+
+		var got, err = epd.ReadTemperature()
+		if err != nil {
+			t.Errorf("ReadTemperature() with register % X failed: %v", tt.register, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ReadTemperature() with register % X = %v, want %v", tt.register, got, tt.want)
+		}
+	}
+
+	var epd = New(nop{}, nop{}, nop{}, nop{}, func(...byte) {})
+	if _, err := epd.ReadTemperature(); err != ErrReadUnsupported {
+		t.Errorf("ReadTemperature() on a V1 panel returned %v, want ErrReadUnsupported", err)
+	}
+}
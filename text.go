@@ -0,0 +1,123 @@
+package epd
+
+import (
+	"image"
+	"image/draw"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// DrawText draws the string s onto the frame buffer using the given font face
+// pt is the top-left corner of the text's bounding box (as returned by MeasureText).
+// The text is only rendered onto the display once Commit is called.
+func (epd *EPD) DrawText(s string, face font.Face, pt image.Point) {
+	text(frame{epd}, s, face, pt, image.Black)
+}
+
+// Align defines the horizontal alignment of text within a box
+type Align uint8
+
+const (
+	AlignLeft Align = iota
+	AlignCenter
+	AlignRight
+)
+
+// DrawTextAligned draws the string s onto the region r of the frame buffer, aligned horizontally as per align
+//
+// The text is placed at the top of r. If it is wider than r, it is truncated with an ellipsis, and anything
+// that still doesn't fit is clipped. The text is only rendered onto the display once Commit is called.
+func (epd *EPD) DrawTextAligned(s string, face font.Face, r image.Rectangle, align Align) {
+	epd.aligned(s, face, r, align, image.Black)
+}
+
+// aligned draws the string s in the given color onto the region r of the frame buffer as described by DrawTextAligned
+func (epd *EPD) aligned(s string, face font.Face, r image.Rectangle, align Align, color *image.Uniform) {
+	s = truncate(s, face, r.Dx())
+
+	var pt = r.Min
+	switch w := MeasureText(s, face).X; align {
+	case AlignCenter:
+		pt.X += (r.Dx() - w) / 2
+	case AlignRight:
+		pt.X += r.Dx() - w
+	}
+	text(clipped{frame{epd}, r}, s, face, pt, color)
+}
+
+// text draws the string s in the given color onto dst using the given font face
+// with pt as the top-left corner of the text's box
+func text(dst draw.Image, s string, face font.Face, pt image.Point, color *image.Uniform) {
+	var d = font.Drawer{
+		Dst:  dst,
+		Src:  color,
+		Face: face,
+		Dot:  fixed.Point26_6{X: fixed.I(pt.X), Y: fixed.I(pt.Y) + face.Metrics().Ascent},
+	}
+	d.DrawString(s)
+}
+
+// truncate shortens the string s, appending an ellipsis, until it fits in the given width
+func truncate(s string, face font.Face, width int) string {
+	if MeasureText(s, face).X <= width {
+		return s
+	}
+
+	var ellipsis = "…"
+	if _, ok := face.GlyphAdvance('…'); !ok {
+		ellipsis = "..."
+	}
+
+	var runes = []rune(s)
+	for n := len(runes) - 1; n > 0; n-- {
+		if t := string(runes[:n]) + ellipsis; MeasureText(t, face).X <= width {
+			return t
+		}
+	}
+	return ellipsis
+}
+
+// MeasureText returns the size of the bounding box of the string s when drawn using the given font face
+// The width is the advance of the string and the height spans the face's ascent and descent,
+// which is the same box DrawText positions the text in.
+func MeasureText(s string, face font.Face) image.Point {
+	var m = face.Metrics()
+	return image.Point{X: font.MeasureString(face, s).Ceil(), Y: (m.Ascent + m.Descent).Ceil()}
+}
+
+// wrap breaks the string s into lines that fit in the given width when drawn using the given font face
+// Lines are broken at whitespace where possible; words wider than the width are broken up.
+// Explicit newlines in s are preserved.
+func wrap(s string, face font.Face, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		var line string
+		for _, word := range strings.Fields(paragraph) {
+			var candidate = word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if MeasureText(candidate, face).X <= width {
+				line = candidate
+				continue
+			}
+
+			if line != "" {
+				lines = append(lines, line)
+			}
+			for line = word; MeasureText(line, face).X > width && len([]rune(line)) > 1; {
+				var runes = []rune(line)
+				var n = len(runes) - 1
+				for n > 1 && MeasureText(string(runes[:n]), face).X > width {
+					n--
+				}
+				lines = append(lines, string(runes[:n]))
+				line = string(runes[n:])
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
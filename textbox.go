@@ -0,0 +1,41 @@
+package epd
+
+import (
+	"image"
+
+	"golang.org/x/image/font"
+)
+
+// TextBox holds the options used by DrawTextBox
+type TextBox struct {
+	Border  int   // width of the border drawn along the edges of the box; zero draws no border
+	Padding int   // space between the border and the text
+	Align   Align // horizontal alignment of each line of text
+	Invert  bool  // draws light text on a dark box instead of dark text on a light one
+}
+
+// DrawTextBox draws a box over the region r of the frame buffer and word-wraps the string s inside of it
+//
+// Lines of text start at the top of the box (inside the border and padding) and any lines that don't fit
+// in the box are left out. The text is only rendered onto the display once Commit is called.
+func (epd *EPD) DrawTextBox(r image.Rectangle, s string, face font.Face, opts TextBox) {
+	var color = image.Black
+	if opts.Invert {
+		color = image.White
+	}
+
+	epd.fill(r, opts.Invert)
+	if opts.Border > 0 {
+		epd.stroke(r, opts.Border)
+	}
+
+	var inner = r.Inset(opts.Border + opts.Padding)
+	var lh = MeasureText("", face).Y
+	for i, line := range wrap(s, face, inner.Dx()) {
+		var y = inner.Min.Y + i*lh
+		if y+lh > inner.Max.Y {
+			break
+		}
+		epd.aligned(line, face, image.Rect(inner.Min.X, y, inner.Max.X, y+lh), opts.Align, color)
+	}
+}
@@ -0,0 +1,47 @@
+package epd
+
+import (
+	"image"
+
+	"golang.org/x/image/font"
+)
+
+// TextField is a single line of text at a fixed position on the display whose content changes over time,
+// such as a clock or a temperature readout
+//
+// Each change clears the previous text and draws the new one onto the frame buffer, and then renders only
+// the region covering both of them. The device is expected to be in PartialUpdate mode.
+type TextField struct {
+	epd  *EPD
+	face font.Face
+	pt   image.Point     // top-left corner of the text's box
+	prev image.Rectangle // box of the text currently shown
+}
+
+// NewTextField creates a new (empty) TextField drawn using the given font face with pt as the top-left corner
+// of the text's box (as in DrawText)
+func NewTextField(epd *EPD, face font.Face, pt image.Point) *TextField {
+	return &TextField{epd: epd, face: face, pt: pt}
+}
+
+// Set replaces the text shown in the field with s and renders the change onto the display
+// Nothing is rendered if neither the previous nor the new text are visible on the display.
+func (f *TextField) Set(s string) error {
+	var box = image.Rectangle{Min: f.pt, Max: f.pt.Add(MeasureText(s, f.face))}
+	if s == "" {
+		box = image.Rectangle{}
+	}
+
+	// the changes are rendered right away, so they don't leave the frame buffer ahead of the device
+	var dirty = f.epd.dirty
+	f.epd.fill(f.prev, false)
+	text(clipped{frame{f.epd}, box}, s, f.face, f.pt, image.Black)
+	f.epd.dirty = dirty
+
+	var r = f.prev.Union(box).Intersect(f.epd.bounds())
+	f.prev = box
+	if r.Empty() {
+		return nil
+	}
+	return f.epd.update(r)
+}
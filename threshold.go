@@ -0,0 +1,74 @@
+package epd
+
+import (
+	"image"
+	"image/color"
+)
+
+// DrawOtsu renders the given image onto the display like Draw, but separates dark and light pixels using a threshold
+// picked by Otsu's method from the image's histogram, rather than the fixed cutoff. This adapts to both dark and
+// bright images without any manual tuning.
+func (epd *EPD) DrawOtsu(img image.Image) error {
+	img = epd.preprocess(img)
+	if !epd.Fits(img) {
+		return ErrInvalidImageSize
+	}
+	if _, uniform := img.(*image.Uniform); uniform {
+		return epd.render(img) // nothing to adapt to
+	}
+	return epd.render(threshold(img, otsu(histogram(img))))
+}
+
+// histogram returns the histogram of the luminance of the image's pixels
+func histogram(img image.Image) (hist [256]int) {
+	var b = img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			hist[luminance(img.At(x, y))]++
+		}
+	}
+	return hist
+}
+
+// otsu returns the threshold that maximises the between-class variance of the histogram (Otsu's method)
+// Luminance values less than or equal to the threshold are considered dark.
+func otsu(hist [256]int) uint8 {
+	var total, sum int
+	for i, n := range hist {
+		total += n
+		sum += i * n
+	}
+
+	var best float64
+	var threshold uint8
+	var wb, sumb int // weight and sum of the background (dark) class
+	for t, n := range hist {
+		wb += n
+		sumb += t * n
+		var wf = total - wb
+		if wb == 0 || wf == 0 {
+			continue
+		}
+
+		var mb, mf = float64(sumb) / float64(wb), float64(sum-sumb) / float64(wf)
+		if v := float64(wb) * float64(wf) * (mb - mf) * (mb - mf); v > best {
+			best, threshold = v, uint8(t)
+		}
+	}
+	return threshold
+}
+
+// threshold returns a black and white copy of the image where every pixel whose luminance is
+// less than or equal to t is black
+func threshold(img image.Image, t uint8) *image.Gray {
+	var b = img.Bounds()
+	var out = image.NewGray(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if luminance(img.At(x, y)) > t {
+				out.SetGray(x, y, color.Gray{Y: 0xFF})
+			}
+		}
+	}
+	return out
+}
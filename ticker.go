@@ -0,0 +1,42 @@
+package epd
+
+import (
+	"image"
+	"image/color"
+)
+
+// Ticker scrolls a tall virtual canvas vertically across the display
+// The device is expected to be in PartialUpdate mode so that each step only refreshes the changed pixels.
+type Ticker struct {
+	epd    *EPD
+	canvas image.Image
+	offset int // row of the canvas shown at the top of the display
+}
+
+// NewTicker creates a new Ticker over the canvas which must be as wide as the display, but can be of any height
+func NewTicker(epd *EPD, canvas image.Image) (*Ticker, error) {
+	if canvas.Bounds().Dx() != epd.Width || canvas.Bounds().Dy() == 0 {
+		return nil, ErrInvalidImageSize
+	}
+	return &Ticker{epd: epd, canvas: canvas}, nil
+}
+
+// Advance scrolls the canvas up by n pixels (down if n is negative) and renders the visible window
+// The canvas wraps around, so scrolling past its end continues from its top.
+func (t *Ticker) Advance(n int) error {
+	var h = t.canvas.Bounds().Dy()
+	t.offset = ((t.offset+n)%h + h) % h
+	return t.epd.DrawRegion(tickerWindow{t}, t.epd.bounds())
+}
+
+// tickerWindow is the display-sized view into a ticker's canvas at its current offset
+type tickerWindow struct{ *Ticker }
+
+func (w tickerWindow) ColorModel() color.Model { return w.canvas.ColorModel() }
+
+func (w tickerWindow) Bounds() image.Rectangle { return w.epd.bounds() }
+
+func (w tickerWindow) At(x, y int) color.Color {
+	var b = w.canvas.Bounds()
+	return w.canvas.At(b.Min.X+x, b.Min.Y+(w.offset+y)%b.Dy())
+}
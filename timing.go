@@ -0,0 +1,20 @@
+package epd
+
+import "time"
+
+// FrameTransmitTime estimates the time it takes to transmit a complete frame to the device over the SPI bus
+// based on SPIHz, including the addressing commands sent along with each row. It doesn't account for the time spent
+// toggling the pins or waiting on the device. It returns zero if SPIHz isn't set.
+func (epd *EPD) FrameTransmitTime() time.Duration {
+	// each row is preceded by the cursor (2 commands, 3 data bytes) and WRITE_RAM (1 command) bytes
+	// and the whole frame is preceded by the window setup (2 commands, 6 data bytes)
+	return epd.transmitTime(epd.Height*(epd.stride()+6) + 8)
+}
+
+// transmitTime estimates the time it takes to transmit n bytes over the SPI bus based on SPIHz
+func (epd *EPD) transmitTime(n int) time.Duration {
+	if epd.SPIHz <= 0 {
+		return 0
+	}
+	return time.Duration(int64(n) * 8 * int64(time.Second) / int64(epd.SPIHz))
+}
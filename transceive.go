@@ -0,0 +1,54 @@
+package epd
+
+import "errors"
+
+// ErrReadUnsupported is returned by features that need to read from the device if no Transceive is configured
+var ErrReadUnsupported = errors.New("reading from device is not supported")
+
+// Transceive is a function that sends the write payload to the device and then reads readLen bytes back
+// over the SPI line. It is only needed by features that read from the device; see SetTransceive.
+type Transceive func(write []byte, readLen int) ([]byte, error)
+
+// SetTransceive configures the function used to read from the device
+// Writes keep going through the Transmit function given to New.
+func (epd *EPD) SetTransceive(transceive Transceive) {
+	epd.transceive = transceive
+}
+
+// Exchange adapts a full-duplex, in-place exchange function (such as go-rpio's SpiExchange)
+// into a Transceive: the data is clocked out and replaced by the data clocked in.
+func Exchange(exchange func(data []byte)) Transceive {
+	return func(write []byte, readLen int) ([]byte, error) {
+		var buf = make([]byte, len(write)+readLen)
+		copy(buf, write)
+		exchange(buf)
+		return buf[len(write):], nil
+	}
+}
+
+// Tx adapts a Tx style function (such as the one provided by periph.io's spi.Conn or TinyGo's machine.SPI),
+// which writes w whilst reading into r, into a Transceive.
+func Tx(tx func(w, r []byte) error) Transceive {
+	return func(write []byte, readLen int) ([]byte, error) {
+		var w, r = make([]byte, len(write)+readLen), make([]byte, len(write)+readLen)
+		copy(w, write)
+		if err := tx(w, r); err != nil {
+			return nil, err
+		}
+		return r[len(write):], nil
+	}
+}
+
+// read sends the command c and reads n bytes of data back from the device
+func (epd *EPD) read(c byte, n int) ([]byte, error) {
+	var bus, ok = epd.iface.(spi)
+	if !ok || epd.transceive == nil {
+		return nil, ErrReadUnsupported
+	}
+
+	epd.command(c)
+	bus.dc.High()
+	bus.cs.Low()
+	defer bus.cs.High()
+	return epd.transceive(nil, n)
+}
@@ -0,0 +1,36 @@
+package epd
+
+import (
+	"image"
+	"image/color"
+)
+
+// DrawUpscaled scales the given image up by an integer factor, repeating each pixel factor times in both directions,
+// and renders it at the center of the display over the Background (see DrawCentered)
+//
+// Unlike resampling, this keeps the hard edges of pixel art and icons authored at a low resolution.
+// It returns ErrInvalidImageSize if the factor is less than 1 or the scaled image doesn't fit within the display.
+func (epd *EPD) DrawUpscaled(img image.Image, factor int) error {
+	if factor < 1 {
+		return ErrInvalidImageSize
+	}
+	return epd.DrawCentered(upscaled{img, factor})
+}
+
+// upscaled is the src image scaled up by an integer factor using nearest-neighbour sampling
+type upscaled struct {
+	src    image.Image
+	factor int
+}
+
+func (u upscaled) ColorModel() color.Model { return u.src.ColorModel() }
+
+func (u upscaled) Bounds() image.Rectangle {
+	var size = u.src.Bounds().Size().Mul(u.factor)
+	return image.Rectangle{Max: size}
+}
+
+func (u upscaled) At(x, y int) color.Color {
+	var min = u.src.Bounds().Min
+	return u.src.At(min.X+x/u.factor, min.Y+y/u.factor)
+}
@@ -0,0 +1,103 @@
+package epd
+
+// Revision identifies a hardware revision of the panel
+type Revision uint8
+
+const (
+	V1 Revision = iota // original 2.9" panel (IL3820 controller)
+	V2                 // 2.9" V2 panel (SSD1680 controller)
+)
+
+// sequenceV2 holds the DISPLAY_UPDATE_CONTROL_2 sequence used to refresh V2 panels in each mode using
+// an uploaded waveform; loadOTP is added to the sequence to load the waveform from the panel's OTP instead
+var sequenceV2 = map[Mode]byte{
+	FullUpdate:    0xC7,
+	PartialUpdate: 0x0F,
+}
+
+// loadOTP are the bits of DISPLAY_UPDATE_CONTROL_2 loading the temperature and the waveform from OTP
+// (along with enabling the clock and analog circuitry required to do so)
+const loadOTP byte = 0xF0
+
+// partialUpdateV2 is the waveform used whilst in partial update mode on V2 panels
+// The first 153 bytes are the lookup table, followed by the end option (0x3F), gate voltage (0x03),
+// source voltages (0x04) and VCOM (0x2C) values.
+var partialUpdateV2 = []byte{
+	0x00, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x80, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x40, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x0A, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x00, 0x00, 0x00,
+	0x22, 0x17, 0x41, 0xB0, 0x32, 0x36,
+}
+
+// initV2 returns the steps initialising a V2 panel into the given mode
+// command+data below is taken from the python sample driver for the V2 panel
+func (epd *EPD) initV2(mode Mode) []Step {
+	var w, h = byte(epd.Width-1) >> 3, epd.Height - 1
+	var steps = []Step{
+		{Command: 0x12, Wait: true}, // SW_RESET
+
+		{Command: 0x01, Data: []byte{byte(h & 0xFF), byte((h >> 8) & 0xFF), epd.GateScan}}, // DRIVER_OUTPUT_CONTROL
+		{Command: 0x11, Data: []byte{0x03}},                                                // DATA_ENTRY_MODE_SETTING
+		{Command: 0x44, Data: []byte{0x00, w}},                                             // SET_RAM_X_ADDRESS_START_END_POSITION
+		{Command: 0x45, Data: []byte{0x00, 0x00, byte(h & 0xFF), byte((h >> 8) & 0xFF)}},   // SET_RAM_Y_ADDRESS_START_END_POSITION
+		{Command: 0x21, Data: []byte{0x00, 0x80}},                                          // DISPLAY_UPDATE_CONTROL_1
+		{Command: 0x4E, Data: []byte{0x00}},                                                // SET_RAM_X_ADDRESS_COUNTER
+		{Command: 0x4F, Data: []byte{0x00, 0x00}, Wait: true},                              // SET_RAM_Y_ADDRESS_COUNTER
+	}
+	if mode == PartialUpdate {
+		return append(steps, epd.partialV2()...)
+	}
+	if wf := epd.Waveforms[mode]; wf != nil {
+		steps = append(steps, waveformSteps(wf)...)
+	}
+	return steps
+}
+
+// partialV2 returns the steps configuring a V2 panel for partial updates: the partial waveform (unless it's loaded
+// from OTP) followed by the display option, border and power control writes
+func (epd *EPD) partialV2() []Step {
+	var steps []Step
+	if wf := epd.Waveforms[PartialUpdate]; wf != nil {
+		steps = waveformSteps(wf)
+	}
+
+	return append(steps,
+		// WRITE_REGISTER_FOR_DISPLAY_OPTION; enables the "ping-pong" mode required for partial updates
+		Step{Command: 0x37, Data: []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00, 0x00}},
+
+		Step{Command: 0x3C, Data: []byte{0x80}}, // BORDER_WAVEFORM_CONTROL
+
+		// DISPLAY_UPDATE_CONTROL_2; enables the clock and analog circuitry
+		Step{Command: 0x22, Data: []byte{0xC0}},
+		Step{Command: 0x20, Wait: true},
+	)
+}
+
+// waveformSteps returns the steps uploading the given waveform into a V2 panel (see Waveforms)
+func waveformSteps(wf []byte) []Step {
+	if len(wf) < 159 {
+		return []Step{{Command: 0x32, Data: wf, Wait: true}} // WRITE_LUT_REGISTER
+	}
+	return []Step{
+		{Command: 0x32, Data: wf[:153], Wait: true}, // WRITE_LUT_REGISTER
+		{Command: 0x3F, Data: wf[153:154]},          // END_OPTION
+		{Command: 0x03, Data: wf[154:155]},          // GATE_DRIVING_VOLTAGE
+		{Command: 0x04, Data: wf[155:158]},          // SOURCE_DRIVING_VOLTAGE
+		{Command: 0x2C, Data: wf[158:159]},          // WRITE_VCOM_REGISTER
+	}
+}
@@ -0,0 +1,44 @@
+package epd
+
+import (
+	"errors"
+	"image"
+)
+
+// ErrLowContrast is returned by Validate if nearly all the pixels of the image end up of the same color
+var ErrLowContrast = errors.New("image is nearly all black or all white after thresholding")
+
+// lowContrast is the fraction of pixels of the same color above which Validate reports ErrLowContrast
+const lowContrast = 0.99
+
+// Validate checks that the image can be drawn onto the display without talking to the device, making it useful
+// for checking content ahead of deployment (eg. in CI).
+//
+// The image is run through the Pipeline and packed exactly like Draw would. It returns ErrInvalidImageSize if
+// the image doesn't fit the display, and ErrLowContrast if more than 99% of the pixels end up of the same color,
+// which usually points to an unsuitable Threshold (or Pipeline). Uniform images are never reported as such.
+func (epd *EPD) Validate(img image.Image) error {
+	img = epd.preprocess(img)
+	if !epd.Fits(img) {
+		return ErrInvalidImageSize
+	}
+	if _, uniform := img.(*image.Uniform); uniform {
+		return nil
+	}
+
+	var buf, stride = epd.pack(img), epd.stride()
+	var dark int
+	for y := 0; y < epd.Height; y++ {
+		for x := 0; x < epd.Width; x++ {
+			if epd.marked(buf[y*stride+x/8], x) {
+				dark++
+			}
+		}
+	}
+
+	var total = float64(epd.Width * epd.Height)
+	if d := float64(dark); d > lowContrast*total || total-d > lowContrast*total {
+		return ErrLowContrast
+	}
+	return nil
+}
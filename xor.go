@@ -0,0 +1,23 @@
+package epd
+
+import "image"
+
+// DrawXOR inverts the pixels in the region r of the display, rendering it using a partial update
+//
+// Calling it again over the same region restores the original content, making it a cheap way of drawing
+// reversible highlights (such as the pressed state of a button) without storing the content underneath.
+// The region doesn't need to be aligned to the 8-pixel boundaries of the device's RAM, as the pixels are inverted
+// in the driver's frame buffer. The region is clipped to the display. The device is expected to be in
+// PartialUpdate mode.
+func (epd *EPD) DrawXOR(r image.Rectangle) error {
+	if r = r.Intersect(epd.bounds()); r.Empty() {
+		return nil // nothing visible to invert
+	}
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			epd.setpixel(x, y, !epd.pixel(x, y))
+		}
+	}
+	return epd.update(r)
+}
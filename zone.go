@@ -0,0 +1,68 @@
+package epd
+
+import (
+	"bytes"
+	"image"
+)
+
+// Zone is a fixed region of the display (such as a dashboard's header, body or footer) that is drawn
+// and refreshed independently of the rest of the display
+//
+// Each zone retains the last image drawn onto it (in packed form), so redrawing an unchanged image doesn't
+// refresh the display at all, hence nothing else should draw over a zone's region. The device is expected
+// to be in PartialUpdate mode.
+type Zone struct {
+	epd *EPD
+	r   image.Rectangle
+	buf []byte // packed contents of the zone, with a stride of ceil(width/8) bytes
+}
+
+// NewZone creates a new Zone covering the region r of the display
+// The region must lie within the display and its vertical edges must be on 8-pixel boundaries
+// (or on the right edge of the display), so that zones never share a byte of the device's RAM.
+func NewZone(epd *EPD, r image.Rectangle) (*Zone, error) {
+	if r.Empty() || !r.In(epd.bounds()) || r.Min.X%8 != 0 || (r.Max.X%8 != 0 && r.Max.X != epd.Width) {
+		return nil, ErrInvalidImageSize
+	}
+	return &Zone{epd: epd, r: r}, nil
+}
+
+// Bounds returns the region of the display covered by the zone
+func (z *Zone) Bounds() image.Rectangle { return z.r }
+
+// Draw renders the given image, which must be of the same size as the zone, onto the zone's region
+// of the display. If the zone already shows the same content, nothing is refreshed.
+func (z *Zone) Draw(img image.Image) error {
+	var _, uniform = img.(*image.Uniform)
+	if !uniform && img.Bounds().Size() != z.r.Size() {
+		return ErrInvalidImageSize
+	}
+
+	var n, min = (z.r.Dx() + 7) / 8, img.Bounds().Min
+	if uniform {
+		min = image.Point{}
+	}
+	var darkAt = z.epd.darkAt(img)
+
+	var buf = make([]byte, n*z.r.Dy())
+	for y := 0; y < z.r.Dy(); y++ {
+		for i := 0; i < n; i++ {
+			var b = z.epd.white()
+			for x := i * 8; x < i*8+8 && x < z.r.Dx(); x++ {
+				if darkAt(min.X+x, min.Y+y) {
+					z.epd.mark(&b, x, true)
+				}
+			}
+			buf[y*n+i] = b
+		}
+	}
+
+	if bytes.Equal(buf, z.buf) {
+		return nil // nothing changed
+	}
+	if err := z.epd.DrawBytesAt(buf, z.r); err != nil {
+		return err
+	}
+	z.buf = buf
+	return nil
+}